@@ -35,8 +35,32 @@ func MapValues[K any, V any, TO any](fromIterator Iterator[K, V], mapperFunc fun
 	return arr
 }
 
-// Finds first entry in sx.Map or sx.Array where the condition is true and returns a key/value pair
-func FindFirstWhere[K comparable, V any](m Map[K, V], condition func(key K, value V) bool) Optional[Pair[K, V]] {
+// Maps the Iterator Values to a new type, collecting all errors instead of stopping at the first one
+//
+// Returns the values of the successful mappings and the errors of the failed ones, both in order
+func MapValuesResult[K any, V any, TO any](fromIterator Iterator[K, V], mapperFunc func(key K, value V) Result[TO]) (Array[TO], *ErrorList) {
+	var arr = NewArray[TO]()
+	var errs = NewErrorList()
+	for ; fromIterator.Ok(); fromIterator.Next() {
+		var val = mapperFunc(fromIterator.Key(), fromIterator.Value())
+		if val.Ok() {
+			arr.Push(val.Value())
+		} else {
+			errs.Add(val.Err())
+		}
+	}
+	return arr, errs
+}
+
+// Like MapValuesResult, but for mapper functions that throw instead of returning a result
+func MapValuesTry[K any, V any, TO any](fromIterator Iterator[K, V], mapperFunc func(key K, value V) TO) (Array[TO], *ErrorList) {
+	return MapValuesResult(fromIterator, func(key K, value V) Result[TO] {
+		return Try(func() TO { return mapperFunc(key, value) })
+	})
+}
+
+// Finds first entry in sx.Map, sx.Array (or any other sx.Iterable) where the condition is true and returns a key/value pair
+func FindFirstWhere[K any, V any](m Iterable[K, V], condition func(key K, value V) bool) Optional[Pair[K, V]] {
 	for it := m.NewIterator(); it.Ok(); it.Next() {
 		if condition(it.Key(), it.Value()) {
 			return NewOptionalFrom(Pair[K, V]{it.Key(), it.Value()})
@@ -45,8 +69,8 @@ func FindFirstWhere[K comparable, V any](m Map[K, V], condition func(key K, valu
 	return NewOptional[Pair[K, V]]()
 }
 
-// Finds all entries in sx.Map or sx.Array where the condition is true and returns a list of key/value pairs
-func FindAll[K comparable, V any](m Map[K, V], condition func(key K, value V) bool) Array[Pair[K, V]] {
+// Finds all entries in sx.Map, sx.Array (or any other sx.Iterable) where the condition is true and returns a list of key/value pairs
+func FindAll[K any, V any](m Iterable[K, V], condition func(key K, value V) bool) Array[Pair[K, V]] {
 	var result = NewArray[Pair[K, V]]()
 	for it := m.NewIterator(); it.Ok(); it.Next() {
 		if condition(it.Key(), it.Value()) {
@@ -56,8 +80,8 @@ func FindAll[K comparable, V any](m Map[K, V], condition func(key K, value V) bo
 	return result
 }
 
-// Checks if a sx.Map or sx.Array contains a certain value (for keys we can just use map.Has(key))
-func ContainsValue[K comparable, V comparable](m Map[K, V], value V) bool {
+// Checks if a sx.Map, sx.Array (or any other sx.Iterable) contains a certain value (for keys we can just use map.Has(key))
+func ContainsValue[K any, V comparable](m Iterable[K, V], value V) bool {
 	return !FindFirstWhere(m, func(_ K, v V) bool { return v == value }).IsEmpty()
 }
 
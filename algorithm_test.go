@@ -2,6 +2,7 @@
 package sx_test
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -184,3 +185,29 @@ type JsonTestStruct struct {
 type JsonRecursiveTestStruct struct {
 	R *JsonRecursiveTestStruct
 }
+
+func TestMapValuesResult(t *testing.T) {
+	var before = sx.NewArrayFrom("1", "x", "3", "y")
+	var parse = func(k int, v string) sx.Result[int] {
+		return sx.NewResultFromTuple(strconv.Atoi(v))
+	}
+	var after, errs = sx.MapValuesResult(before.NewIterator(), parse)
+	if after.Length() != 2 || after.Get(0).Value() != 1 || after.Get(1).Value() != 3 || errs.Length() != 2 {
+		t.FailNow()
+	}
+	after, errs = sx.MapValuesResult(sx.NewArrayFrom("4").NewIterator(), parse)
+	if after.Length() != 1 || errs.Err() != nil {
+		t.FailNow()
+	}
+}
+
+func TestMapValuesTry(t *testing.T) {
+	var before = sx.NewArrayFrom(2, 0, 5)
+	var after, errs = sx.MapValuesTry(before.NewIterator(), func(k int, v int) int {
+		sx.ThrowIf(v == 0, "division by zero")
+		return 10 / v
+	})
+	if after.Length() != 2 || after.Get(1).Value() != 2 || errs.Error() != "division by zero" {
+		t.FailNow()
+	}
+}
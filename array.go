@@ -1,6 +1,8 @@
 // SPDX-License-Identifier: 0BSD
 package sx
 
+import "iter"
+
 var _ Container = &arrayImpl[int]{}
 var _ Array[int] = &arrayImpl[int]{}
 var _ Array[int] = NewArray[int]()
@@ -108,6 +110,14 @@ func (v arrayImpl[V]) NewIterator() Iterator[int, V] {
 	return &it
 }
 
+func (v *arrayImpl[V]) All() iter.Seq2[int, V] {
+	return Seq2[int, V](v)
+}
+
+func (v *arrayImpl[V]) Values() iter.Seq[V] {
+	return Seq[int, V](v)
+}
+
 type SliceIterator[K int, V any] struct {
 	slice []V
 	index int
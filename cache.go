@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"iter"
+	"time"
+)
+
+var _ Container = &cacheImpl[string, int]{}
+var _ Map[string, int] = NewLRUCache[string, int](1)
+var _ Cache[string, int] = NewTTLCache[string, int](1, time.Second)
+var _ Iterable[string, int] = &cacheImpl[string, int]{}
+var _ Iterator[string, int] = &cacheIterator[string, int]{}
+
+// Counters of a cache, e.g. to tune its capacity
+type CacheStats struct {
+	Hits        int // Get found a valid entry
+	Misses      int // Get found no entry, or only an expired one
+	Evictions   int // entries removed because the capacity was exceeded
+	Expirations int // entries removed because their time to live was over
+}
+
+// Ratio of hits to all Get calls, 0 if Get was never called
+func (stats CacheStats) HitRate() float64 {
+	if stats.Hits+stats.Misses == 0 {
+		return 0
+	}
+	return float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+}
+
+// Creates a cache that holds at most 'capacity' entries
+//
+// Put evicts the least recently used entry when the cache is full.
+// Get marks an entry as recently used, Has and iteration do not.
+// Iteration goes from the least to the most recently used entry.
+// The cache is not goroutine-safe
+func NewLRUCache[K comparable, V any](capacity int) Cache[K, V] {
+	ThrowIf(capacity < 1, "Fatal error: cache capacity must be at least 1")
+	return newCache[K, V](capacity, 0, time.Now)
+}
+
+// Creates an LRU cache whose entries expire 'ttl' after they were put
+//
+// A capacity < 1 means the cache is unbounded and entries are only removed when they expire.
+// The optional clock replaces time.Now, e.g. for deterministic tests
+func NewTTLCache[K comparable, V any](capacity int, ttl time.Duration, clock ...func() time.Time) Cache[K, V] {
+	ThrowIf(ttl <= 0, "Fatal error: cache time to live must be positive")
+	var now = time.Now
+	if len(clock) > 0 {
+		now = clock[0]
+	}
+	return newCache[K, V](capacity, ttl, now)
+}
+
+func newCache[K comparable, V any](capacity int, ttl time.Duration, clock func() time.Time) *cacheImpl[K, V] {
+	var c = &cacheImpl[K, V]{capacity: capacity, ttl: ttl, clock: clock}
+	c.entries.Map = make(map[K]*orderedMapEntry[K, cacheEntry[V]])
+	return c
+}
+
+type cacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+type cacheImpl[K comparable, V any] struct {
+	entries  orderedMapImpl[K, cacheEntry[V]] // least recently used first
+	capacity int                              // < 1 means unbounded
+	ttl      time.Duration                    // 0 means entries never expire
+	clock    func() time.Time
+	onEvict  func(key K, value V)
+	stats    CacheStats
+}
+
+func (c *cacheImpl[K, V]) OnEvict(callback func(key K, value V)) {
+	c.onEvict = callback
+}
+
+func (c *cacheImpl[K, V]) Stats() CacheStats {
+	return c.stats
+}
+
+// Removes expired entries first, so the result is O(n) for caches with a time to live
+func (c *cacheImpl[K, V]) Length() int {
+	c.removeExpired()
+	return c.entries.Length()
+}
+
+func (c *cacheImpl[K, V]) IsEmpty() bool {
+	return c.Length() == 0
+}
+
+func (c *cacheImpl[K, V]) Has(key K) bool {
+	var entry, ok = c.entries.Map[key]
+	return ok && !c.isExpired(entry.value)
+}
+
+func (c *cacheImpl[K, V]) Get(key K) Result[V] {
+	var entry, ok = c.entries.Map[key]
+	if ok && c.isExpired(entry.value) {
+		c.expire(entry)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return NewResultError[V]("Fatal error: Key does not exist")
+	}
+	c.stats.Hits++
+	c.entries.moveToBack(entry)
+	return NewResultFrom(entry.value.value)
+}
+
+// Inserts or replaces the value and marks it as most recently used, the time to live starts again
+func (c *cacheImpl[K, V]) Put(key K, value V) bool {
+	var newEntry = cacheEntry[V]{value: value}
+	if c.ttl > 0 {
+		newEntry.expires = c.clock().Add(c.ttl)
+	}
+	if entry, ok := c.entries.Map[key]; ok {
+		entry.value = newEntry
+		c.entries.moveToBack(entry)
+		return true
+	}
+	c.entries.Put(key, newEntry)
+	if c.capacity > 0 && c.entries.Length() > c.capacity {
+		c.removeExpired()
+	}
+	for c.capacity > 0 && c.entries.Length() > c.capacity {
+		var oldest = c.entries.first
+		c.entries.Drop(oldest.key)
+		c.stats.Evictions++
+		c.notify(oldest)
+	}
+	return true
+}
+
+// Removes the entry without calling the eviction callback
+func (c *cacheImpl[K, V]) Drop(key K) bool {
+	return c.entries.Drop(key)
+}
+
+// Iterates from the least to the most recently used entry, skipping expired entries
+//
+// The order is taken when the iterator is created, so Get and Put while iterating don't change it
+func (c *cacheImpl[K, V]) NewIterator() Iterator[K, V] {
+	var entries = make([]*orderedMapEntry[K, cacheEntry[V]], 0, c.entries.Length())
+	for entry := c.entries.first; entry != nil; entry = entry.next {
+		entries = append(entries, entry)
+	}
+	return &cacheIterator[K, V]{cache: c, entries: entries}
+}
+
+func (c *cacheImpl[K, V]) All() iter.Seq2[K, V] {
+	return Seq2[K, V](c)
+}
+
+func (c *cacheImpl[K, V]) isExpired(entry cacheEntry[V]) bool {
+	return c.ttl > 0 && !c.clock().Before(entry.expires)
+}
+
+func (c *cacheImpl[K, V]) removeExpired() {
+	if c.ttl <= 0 {
+		return
+	}
+	for entry := c.entries.first; entry != nil; {
+		var next = entry.next
+		if c.isExpired(entry.value) {
+			c.expire(entry)
+		}
+		entry = next
+	}
+}
+
+func (c *cacheImpl[K, V]) expire(entry *orderedMapEntry[K, cacheEntry[V]]) {
+	c.entries.Drop(entry.key)
+	c.stats.Expirations++
+	c.notify(entry)
+}
+
+func (c *cacheImpl[K, V]) notify(entry *orderedMapEntry[K, cacheEntry[V]]) {
+	if c.onEvict != nil {
+		c.onEvict(entry.key, entry.value.value)
+	}
+}
+
+type cacheIterator[K comparable, V any] struct {
+	cache   *cacheImpl[K, V]
+	entries []*orderedMapEntry[K, cacheEntry[V]]
+	matched bool // the first entry is still in the cache and not expired
+}
+
+func (it *cacheIterator[K, V]) Ok() bool {
+	for ; !it.matched && len(it.entries) > 0; it.entries = it.entries[1:] {
+		if !it.entries[0].dropped && !it.cache.isExpired(it.entries[0].value) {
+			it.matched = true
+			return true
+		}
+	}
+	return it.matched
+}
+
+func (it *cacheIterator[K, V]) Key() K   { it.Ok(); return it.entries[0].key }
+func (it *cacheIterator[K, V]) Value() V { it.Ok(); return it.entries[0].value.value }
+func (it *cacheIterator[K, V]) Next() {
+	if it.Ok() {
+		it.entries = it.entries[1:]
+		it.matched = false
+	}
+}
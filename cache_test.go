@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ZeroBsd/sx"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time           { return clock.now }
+func (clock *fakeClock) Advance(by time.Duration) { clock.now = clock.now.Add(by) }
+
+func TestLRUCache(t *testing.T) {
+	var cache = sx.NewLRUCache[string, int](2)
+	var evicted = []string{}
+	cache.OnEvict(func(key string, value int) { evicted = append(evicted, sx.Str(key, value)) })
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	if cache.Length() != 2 || !cache.Has("a") || !cache.Has("b") {
+		t.FailNow()
+	}
+	// 'a' becomes the most recently used entry, so 'b' is evicted
+	if cache.Get("a").Value() != 1 {
+		t.FailNow()
+	}
+	cache.Put("c", 3)
+	if cache.Has("b") || !cache.Has("a") || !cache.Has("c") || !reflect.DeepEqual(evicted, []string{"b2"}) {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(orderedKeys[string, int](cache), []string{"a", "c"}) {
+		t.FailNow()
+	}
+
+	// updating marks the entry as recently used, too
+	cache.Put("a", 11)
+	cache.Put("d", 4)
+	if !reflect.DeepEqual(orderedKeys[string, int](cache), []string{"a", "d"}) || cache.Get("a").Value() != 11 {
+		t.FailNow()
+	}
+
+	// dropping does not call the callback
+	if !cache.Drop("a") || cache.Drop("a") || len(evicted) != 2 {
+		t.FailNow()
+	}
+
+	if cache.Get("x").Ok() {
+		t.FailNow()
+	}
+	var stats = cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 2 || stats.Expirations != 0 || stats.HitRate() != 2.0/3.0 {
+		t.FailNow()
+	}
+	if (sx.CacheStats{}).HitRate() != 0 {
+		t.FailNow()
+	}
+	checkMapConformance[string, int](t, cache, "x")
+}
+
+func TestLRUCacheGetWhileIterating(t *testing.T) {
+	var cache = sx.NewLRUCache[int, int](5)
+	for i := range 5 {
+		cache.Put(i, i*10)
+	}
+	var visited = []int{}
+	for key, value := range sx.Seq2(cache) {
+		if cache.Get(key).Value() != value {
+			t.FailNow()
+		}
+		visited = append(visited, key)
+	}
+	if !reflect.DeepEqual(visited, []int{0, 1, 2, 3, 4}) {
+		t.Fatal(visited)
+	}
+
+	// dropped and evicted entries are skipped
+	visited = []int{}
+	for key := range sx.Keys(cache) {
+		if key == 0 {
+			cache.Drop(1)
+			cache.Put(5, 50)
+			cache.Put(6, 60) // evicts 0
+			cache.Put(7, 70) // evicts 2
+		}
+		visited = append(visited, key)
+	}
+	if !reflect.DeepEqual(visited, []int{0, 3, 4}) {
+		t.Fatal(visited)
+	}
+}
+
+func TestLRUCacheInvalidCapacity(t *testing.T) {
+	defer sx.Catch(func(err error) {})
+	sx.NewLRUCache[string, int](0)
+	t.FailNow()
+}
+
+func TestTTLCache(t *testing.T) {
+	var clock = &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var cache = sx.NewTTLCache[string, int](0, time.Minute, clock.Now)
+	var expired = []string{}
+	cache.OnEvict(func(key string, value int) { expired = append(expired, key) })
+
+	cache.Put("a", 1)
+	clock.Advance(30 * time.Second)
+	cache.Put("b", 2)
+	if cache.Get("a").Value() != 1 || cache.Length() != 2 {
+		t.FailNow()
+	}
+
+	// 'a' expires, reading does not extend the time to live
+	clock.Advance(30 * time.Second)
+	if cache.Has("a") || !cache.Has("b") {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(orderedKeys[string, int](cache), []string{"b"}) {
+		t.FailNow()
+	}
+	if cache.Get("a").Ok() || !reflect.DeepEqual(expired, []string{"a"}) {
+		t.FailNow()
+	}
+
+	// putting again restarts the time to live
+	clock.Advance(20 * time.Second)
+	cache.Put("b", 22)
+	clock.Advance(50 * time.Second)
+	if cache.Get("b").Value() != 22 {
+		t.FailNow()
+	}
+	cache.Put("c", 3)
+	clock.Advance(10 * time.Second)
+	if cache.Length() != 1 || !cache.Has("c") || cache.IsEmpty() || !reflect.DeepEqual(expired, []string{"a", "b"}) {
+		t.FailNow()
+	}
+	clock.Advance(time.Hour)
+	if !cache.IsEmpty() {
+		t.FailNow()
+	}
+	var stats = cache.Stats()
+	if stats.Expirations != 3 || stats.Evictions != 0 || stats.Hits != 2 || stats.Misses != 1 {
+		t.FailNow()
+	}
+}
+
+func TestTTLCacheWithCapacity(t *testing.T) {
+	var clock = &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var cache = sx.NewTTLCache[int, string](2, time.Minute, clock.Now)
+	cache.Put(1, "a")
+	clock.Advance(time.Minute)
+	cache.Put(2, "b")
+	// the expired entry makes room, so no valid entry is evicted
+	cache.Put(3, "c")
+	if cache.Has(1) || !cache.Has(2) || !cache.Has(3) {
+		t.FailNow()
+	}
+	cache.Put(4, "d")
+	if cache.Has(2) || !cache.Has(3) || !cache.Has(4) {
+		t.FailNow()
+	}
+	if stats := cache.Stats(); stats.Expirations != 1 || stats.Evictions != 1 {
+		t.FailNow()
+	}
+	var keys = []int{}
+	for key, value := range sx.Seq2(cache) {
+		keys = append(keys, key)
+		_ = value
+	}
+	if !reflect.DeepEqual(keys, []int{3, 4}) {
+		t.FailNow()
+	}
+
+	// the real clock is used by default
+	var realTime = sx.NewTTLCache[int, int](1, time.Hour)
+	realTime.Put(1, 1)
+	if realTime.Get(1).Value() != 1 {
+		t.FailNow()
+	}
+}
+
+func TestTTLCacheInvalidTimeToLive(t *testing.T) {
+	defer sx.Catch(func(err error) {})
+	sx.NewTTLCache[string, int](1, 0)
+	t.FailNow()
+}
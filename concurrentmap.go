@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"iter"
+	"sync"
+)
+
+var _ Container = &concurrentMapImpl[string, int]{}
+var _ Map[string, int] = NewConcurrentMap[string, int]()
+var _ ConcurrentMap[string, int] = &concurrentMapImpl[string, int]{}
+var _ Iterable[string, int] = &concurrentMapImpl[string, int]{}
+
+// Creates a map that can be shared between goroutines
+//
+// All operations are guarded by a read/write lock.
+// Iterators work on a snapshot, so they never race with writers
+func NewConcurrentMap[K comparable, V any]() ConcurrentMap[K, V] {
+	return &concurrentMapImpl[K, V]{values: make(map[K]V)}
+}
+
+func NewConcurrentMapFrom[K comparable, V any](values map[K]V) ConcurrentMap[K, V] {
+	var m = NewConcurrentMap[K, V]()
+	for key, value := range values {
+		m.Put(key, value)
+	}
+	return m
+}
+
+type concurrentMapImpl[K comparable, V any] struct {
+	lock   sync.RWMutex
+	values map[K]V
+}
+
+func (m *concurrentMapImpl[K, V]) Length() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.values)
+}
+
+func (m *concurrentMapImpl[K, V]) IsEmpty() bool {
+	return m.Length() == 0
+}
+
+func (m *concurrentMapImpl[K, V]) Has(key K) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var _, ok = m.values[key]
+	return ok
+}
+
+func (m *concurrentMapImpl[K, V]) Get(key K) Result[V] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var value, ok = m.values[key]
+	if !ok {
+		return NewResultError[V]("Fatal error: Key does not exist")
+	}
+	return NewResultFrom(value)
+}
+
+func (m *concurrentMapImpl[K, V]) Put(key K, value V) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.values[key] = value
+	return true
+}
+
+func (m *concurrentMapImpl[K, V]) Drop(key K) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var _, ok = m.values[key]
+	delete(m.values, key)
+	return ok
+}
+
+func (m *concurrentMapImpl[K, V]) GetOrPut(key K, value V) (actual V, loaded bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if actual, loaded = m.values[key]; loaded {
+		return actual, loaded
+	}
+	m.values[key] = value
+	return value, false
+}
+
+// Calls the remapper with the current value (if present) while holding the lock
+//
+// The result of the remapper is stored, an empty Optional drops the key.
+// The remapper must not access the map itself, this would deadlock
+func (m *concurrentMapImpl[K, V]) Compute(key K, remapper func(old Optional[V]) Optional[V]) Optional[V] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var old = NewOptional[V]()
+	if value, ok := m.values[key]; ok {
+		old = NewOptionalFrom(value)
+	}
+	var result = remapper(old)
+	if result.IsEmpty() {
+		delete(m.values, key)
+	} else {
+		m.values[key] = result.Value()
+	}
+	return result
+}
+
+// Values are compared with '==', so V must be comparable at runtime (same as sync.Map)
+func (m *concurrentMapImpl[K, V]) CompareAndSwap(key K, old V, new V) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var current, ok = m.values[key]
+	if !ok || any(current) != any(old) {
+		return false
+	}
+	m.values[key] = new
+	return true
+}
+
+// Iterates over a snapshot, changes after the creation of the iterator are not visible
+func (m *concurrentMapImpl[K, V]) NewIterator() Iterator[K, V] {
+	return NewMapIterator(m.snapshot())
+}
+
+// Iterates over a snapshot, taken when the range loop starts
+func (m *concurrentMapImpl[K, V]) All() iter.Seq2[K, V] {
+	return Seq2[K, V](m)
+}
+
+func (m *concurrentMapImpl[K, V]) snapshot() map[K]V {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var snapshot = make(map[K]V, len(m.values))
+	for key, value := range m.values {
+		snapshot[key] = value
+	}
+	return snapshot
+}
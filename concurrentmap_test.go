@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestConcurrentMap(t *testing.T) {
+	var m = sx.NewConcurrentMapFrom(map[string]int{"a": 1})
+	if m.IsEmpty() || m.Length() != 1 || !m.Has("a") || m.Get("a").Value() != 1 || m.Get("b").Ok() {
+		t.FailNow()
+	}
+	if actual, loaded := m.GetOrPut("a", 2); !loaded || actual != 1 {
+		t.FailNow()
+	}
+	if actual, loaded := m.GetOrPut("b", 2); loaded || actual != 2 || m.Get("b").Value() != 2 {
+		t.FailNow()
+	}
+	if m.CompareAndSwap("b", 3, 4) || m.CompareAndSwap("c", 2, 4) || m.Get("b").Value() != 2 {
+		t.FailNow()
+	}
+	if !m.CompareAndSwap("b", 2, 4) || m.Get("b").Value() != 4 {
+		t.FailNow()
+	}
+	if !m.Drop("b") || m.Drop("b") || m.Has("b") {
+		t.FailNow()
+	}
+	checkMapConformance[string, int](t, m, "x")
+	checkMapPutDropConformance(t, func() sx.Map[int, string] { return sx.NewConcurrentMap[int, string]() })
+}
+
+func TestConcurrentMapCompute(t *testing.T) {
+	var m = sx.NewConcurrentMap[string, int]()
+	var increment = func(old sx.Optional[int]) sx.Optional[int] { return sx.NewOptionalFrom(old.ValueOr(0) + 1) }
+	if m.Compute("a", increment).Value() != 1 || m.Compute("a", increment).Value() != 2 || m.Get("a").Value() != 2 {
+		t.FailNow()
+	}
+	var drop = func(old sx.Optional[int]) sx.Optional[int] { return sx.NewOptional[int]() }
+	if m.Compute("a", drop).Ok() || m.Has("a") || m.Compute("b", drop).Ok() || !m.IsEmpty() {
+		t.FailNow()
+	}
+}
+
+func TestConcurrentMapIteratorSnapshot(t *testing.T) {
+	var m = sx.NewConcurrentMapFrom(map[int]int{1: 1, 2: 2, 3: 3})
+	var count = 0
+	for it := m.NewIterator(); it.Ok(); it.Next() {
+		count++
+		m.Drop(it.Key()%3 + 1)
+		m.Put(it.Key()+10, it.Value())
+	}
+	if count != 3 {
+		t.FailNow()
+	}
+}
+
+func TestConcurrentMapParallel(t *testing.T) {
+	const goroutines = 8
+	const increments = 1000
+	var m = sx.NewConcurrentMapFrom(map[int]int{-1: 0})
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				m.Compute(0, func(old sx.Optional[int]) sx.Optional[int] { return sx.NewOptionalFrom(old.ValueOr(0) + 1) })
+				m.GetOrPut(g*increments+i+1, i)
+				for value := m.Get(-1).Value(); !m.CompareAndSwap(-1, value, value+1); value = m.Get(-1).Value() {
+				}
+				if i%100 == 0 {
+					for it := m.NewIterator(); it.Ok(); it.Next() {
+						_ = it.Value()
+					}
+					_ = sx.ToJson(m)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Get(0).Value() != goroutines*increments || m.Get(-1).Value() != goroutines*increments {
+		t.Fatal(m.Get(0).Value(), m.Get(-1).Value())
+	}
+	if m.Length() != goroutines*increments+2 {
+		t.FailNow()
+	}
+}
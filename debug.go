@@ -2,36 +2,128 @@
 package sx
 
 import (
-	"fmt"
-	"os"
+	"context"
+	"sync"
 )
 
+// The global debug state is a stack, guarded by a mutex
+//
+// Scopes and contexts (see DebugPush and DebugWithContext) carry their own state,
+// they are the goroutine-friendly alternatives to DebugPushOn/DebugPop
+var debugMutex sync.Mutex
 var debugStack = NewArrayFrom(false)
 
-func DebugIsActive() bool { return debugStack.Peek().ValueOr(false) == true }
+func DebugIsActive() bool {
+	debugMutex.Lock()
+	defer debugMutex.Unlock()
+	return debugStack.Peek().ValueOr(false)
+}
 
-func DebugSetOn()   { debugStack.Pop(); debugStack.Push(true) }
-func DebugSetOff()  { debugStack.Pop(); debugStack.Push(false) }
-func DebugPushOn()  { debugStack.Push(true) }
-func DebugPushOff() { debugStack.Push(false) }
+func DebugSetOn()   { debugSetTop(true) }
+func DebugSetOff()  { debugSetTop(false) }
+func DebugPushOn()  { debugPush(true) }
+func DebugPushOff() { debugPush(false) }
 func DebugPop() {
+	debugMutex.Lock()
+	defer debugMutex.Unlock()
 	if debugStack.Length() > 1 {
 		debugStack.Pop()
 	}
 }
 
-// Print debug messages
-// will not print by default, please call "SetDebugOn" first
-func Debug(values ...string) {
-	if DebugIsActive() {
-		fmt.Fprintln(os.Stderr, StrCat(values...))
+func debugPush(on bool) {
+	debugMutex.Lock()
+	defer debugMutex.Unlock()
+	debugStack.Push(on)
+}
+
+func debugSetTop(on bool) {
+	debugMutex.Lock()
+	defer debugMutex.Unlock()
+	debugStack.Pop()
+	debugStack.Push(on)
+}
+
+// A debug state of its own, independent of the global state and of other scopes
+//
+// Each goroutine (or test) can hold its own scope, e.g. 'scope := sx.DebugPush(true); defer scope.Pop()'.
+// A scope belongs to the goroutine that created it, use Context to hand its state to other goroutines
+type DebugScope struct {
+	on     bool
+	popped bool
+}
+
+// Starts a scope with its own debug state, the global state is not changed
+func DebugPush(on bool) *DebugScope {
+	return &DebugScope{on: on}
+}
+
+// Ends the scope, afterwards it falls back to the global state (see DebugIsActive)
+func (scope *DebugScope) Pop() {
+	scope.popped = true
+}
+
+func (scope *DebugScope) IsActive() bool {
+	if scope.popped {
+		return DebugIsActive()
 	}
+	return scope.on
 }
 
-// Print debug messages
-// will not print by default, please call "SetDebugOn" first
-func DebugAny(values ...any) {
-	if DebugIsActive() {
-		PrintAnyStdErr(values...)
+// Returns a context that carries the state of the scope, see DebugWithContext
+func (scope *DebugScope) Context(ctx context.Context) context.Context {
+	return DebugWithContext(ctx, scope.IsActive())
+}
+
+// Writes debug messages to the DefaultLogger if the scope is active, see Debug
+func (scope *DebugScope) Debug(values ...string) {
+	debugLog(scope.IsActive(), StrCat(values...))
+}
+
+// Writes debug messages to the DefaultLogger if the scope is active, see DebugAny
+func (scope *DebugScope) DebugAny(values ...any) {
+	debugLog(scope.IsActive(), Str(values...))
+}
+
+type debugContextKey struct{}
+
+// Returns a context that carries its own debug state, independent of the global state
+func DebugWithContext(ctx context.Context, on bool) context.Context {
+	return context.WithValue(ctx, debugContextKey{}, on)
+}
+
+// Uses the debug state of the context, falls back to the global state (see DebugIsActive)
+func DebugIsActiveCtx(ctx context.Context) bool {
+	if on, ok := ctx.Value(debugContextKey{}).(bool); ok {
+		return on
 	}
+	return DebugIsActive()
+}
+
+// Writes debug messages to the DefaultLogger at LogLevelDebug
+// will not print by default, please call "SetDebugOn" first (or allow LogLevelDebug in the DefaultLogger)
+func Debug(values ...string) {
+	debugLog(DebugIsActive(), StrCat(values...))
+}
+
+// Writes debug messages to the DefaultLogger at LogLevelDebug
+// will not print by default, please call "SetDebugOn" first (or allow LogLevelDebug in the DefaultLogger)
+func DebugAny(values ...any) {
+	debugLog(DebugIsActive(), Str(values...))
+}
+
+// Writes debug messages to the DefaultLogger, if debugging is active for the context, see DebugIsActiveCtx
+func DebugCtx(ctx context.Context, values ...string) {
+	debugLog(DebugIsActiveCtx(ctx), StrCat(values...))
+}
+
+// Writes debug messages to the DefaultLogger, if debugging is active for the context, see DebugIsActiveCtx
+func DebugAnyCtx(ctx context.Context, values ...any) {
+	debugLog(DebugIsActiveCtx(ctx), Str(values...))
+}
+
+// An active debug state writes the entry regardless of the logger's level and filters.
+// Must be called directly by the exported debug functions, so the caller's function name is found
+func debugLog(active bool, message string) {
+	defaultLogger.write(ReflectFunctionName(2), LogLevelDebug, active, message, nil)
 }
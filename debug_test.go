@@ -2,7 +2,10 @@
 package sx_test
 
 import (
+	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -29,3 +32,99 @@ func TestDebug(t *testing.T) {
 	sx.ThrowIf(sx.DebugIsActive())
 	sx.ThrowIf(sx.DebugIsActive())
 }
+
+func TestDebugScope(t *testing.T) {
+	var on, off = sx.DebugPush(true), sx.DebugPush(false)
+	sx.ThrowIf(!on.IsActive() || off.IsActive() || sx.DebugIsActive())
+	sx.ThrowIf(!sx.DebugIsActiveCtx(on.Context(context.Background())) || sx.DebugIsActiveCtx(off.Context(context.Background())))
+
+	// the global state does not change the scopes, and the other way round
+	sx.DebugPushOn()
+	sx.ThrowIf(!on.IsActive() || off.IsActive() || !sx.DebugIsActive())
+
+	// popped scopes fall back to the global state
+	off.Pop()
+	off.Pop()
+	sx.ThrowIf(!off.IsActive() || !on.IsActive())
+	sx.DebugPop()
+	sx.ThrowIf(off.IsActive() || sx.DebugIsActive())
+	on.Pop()
+	sx.ThrowIf(on.IsActive())
+}
+
+func TestDebugContext(t *testing.T) {
+	var ctx = context.Background()
+	sx.ThrowIf(sx.DebugIsActiveCtx(ctx))
+	sx.DebugCtx(ctx, "not printed")
+	sx.DebugAnyCtx(ctx, "not printed")
+
+	var debugCtx = sx.DebugWithContext(ctx, true)
+	sx.ThrowIf(!sx.DebugIsActiveCtx(debugCtx) || sx.DebugIsActive())
+	sx.DebugCtx(debugCtx, "")
+	sx.DebugAnyCtx(debugCtx, "")
+
+	// the global state is the fallback, the context state wins
+	sx.DebugPushOn()
+	defer sx.DebugPop()
+	sx.ThrowIf(!sx.DebugIsActiveCtx(ctx) || sx.DebugIsActiveCtx(sx.DebugWithContext(ctx, false)))
+}
+
+func TestDebugConcurrency(t *testing.T) {
+	var start, wg sync.WaitGroup
+	var failures = make(chan string, 16)
+	start.Add(1)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(on bool) {
+			defer wg.Done()
+			start.Wait()
+			for j := 0; j < 1000; j++ {
+				var scope = sx.DebugPush(on)
+				var ctx = scope.Context(context.Background())
+				if scope.IsActive() != on || sx.DebugIsActiveCtx(ctx) != on {
+					failures <- sx.Str("scope of goroutine ", on, " changed")
+					return
+				}
+				scope.Pop()
+				if scope.IsActive() {
+					failures <- sx.Str("popped scope of goroutine ", on, " is still active")
+					return
+				}
+			}
+		}(i%2 == 0)
+	}
+	start.Done()
+	wg.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Fatal(failure)
+	}
+	sx.ThrowIf(sx.DebugIsActive())
+}
+
+func TestDebugUsesDefaultLogger(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	sx.DefaultLogger().SetSinks(sink)
+	defer sx.DefaultLogger().SetSinks(sx.NewLogStdErrSink(sx.LogFormatText))
+
+	sx.Debug("hidden")
+	var scope = sx.DebugPush(true)
+	scope.Debug("a", "b")
+	scope.DebugAny("c", 1)
+	scope.Pop()
+	scope.Debug("hidden")
+	sx.DebugPush(false).Debug("hidden")
+	sx.DebugCtx(sx.DebugWithContext(context.Background(), true), "ctx")
+	sx.DebugAnyCtx(context.Background(), "hidden")
+	sx.DefaultLogger().PushLevel(sx.LogLevelDebug)
+	sx.Debug("level")
+	sx.DefaultLogger().PopLevel()
+
+	if strings.Join(sink.Messages().SubSlice(), ",") != "ab,c1,ctx,level" {
+		t.Fatal(sink.Messages().SubSlice())
+	}
+	var entry = sink.Entries().Get(0).Value()
+	if entry.Level != sx.LogLevelDebug || entry.Function != "github.com/ZeroBsd/sx_test.TestDebugUsesDefaultLogger" {
+		t.Fatal(entry.Function)
+	}
+}
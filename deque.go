@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import "iter"
+
+var _ Container = &dequeImpl[int]{}
+var _ Array[int] = &dequeImpl[int]{}
+var _ Deque[int] = NewDeque[int]()
+var _ Queue[int] = NewQueue[int]()
+var _ Stack[int] = NewDeque[int]()
+var _ Iterator[int, int] = &dequeIterator[int]{}
+
+// Creates a double-ended queue, backed by a ring buffer
+//
+// Pushing and popping at both ends is O(1) amortized, index access is O(1).
+// It is also an sx.Array, where Push/Pop/Peek work on the back
+func NewDeque[V any](capacity ...int) Deque[V] {
+	var d = &dequeImpl[V]{}
+	if len(capacity) > 0 && capacity[0] > 0 {
+		d.buffer = make([]V, capacity[0])
+	}
+	return d
+}
+
+func NewDequeFrom[V any](items ...V) Deque[V] {
+	var d = NewDeque[V](len(items))
+	d.PushBack(items...)
+	return d
+}
+
+// Creates a FIFO queue, backed by a ring buffer
+func NewQueue[V any](capacity ...int) Queue[V] {
+	return NewDeque[V](capacity...)
+}
+
+type dequeImpl[V any] struct {
+	buffer []V
+	head   int // index of the first value in the buffer
+	length int
+}
+
+func (d *dequeImpl[V]) Length() int        { return d.length }
+func (d *dequeImpl[V]) IsEmpty() bool      { return d.Length() == 0 }
+func (d *dequeImpl[V]) Has(index int) bool { return index >= 0 && index < d.Length() }
+
+// Converts an index of the deque to an index of the buffer
+func (d *dequeImpl[V]) bufferIndex(index int) int {
+	return (d.head + index) % len(d.buffer)
+}
+
+func (d *dequeImpl[V]) grow(additional int) {
+	var required = d.length + additional
+	if required <= len(d.buffer) {
+		return
+	}
+	var newCapacity = 2 * len(d.buffer)
+	if newCapacity < 8 {
+		newCapacity = 8
+	}
+	for newCapacity < required {
+		newCapacity *= 2
+	}
+	d.resize(newCapacity)
+}
+
+func (d *dequeImpl[V]) resize(newCapacity int) {
+	var newBuffer = make([]V, newCapacity)
+	d.copyTo(newBuffer, 0, d.length)
+	d.buffer = newBuffer
+	d.head = 0
+}
+
+// Copies the values [from, to) in order to the target slice
+func (d *dequeImpl[V]) copyTo(target []V, from int, to int) {
+	if from >= to {
+		return
+	}
+	var start = d.bufferIndex(from)
+	var copied = copy(target, d.buffer[start:min(start+to-from, len(d.buffer))])
+	copy(target[copied:], d.buffer[:to-from-copied])
+}
+
+func (d *dequeImpl[V]) PushBack(values ...V) {
+	d.grow(len(values))
+	for _, value := range values {
+		d.buffer[d.bufferIndex(d.length)] = value
+		d.length++
+	}
+}
+
+func (d *dequeImpl[V]) PushFront(values ...V) {
+	d.grow(len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		d.head = (d.head - 1 + len(d.buffer)) % len(d.buffer)
+		d.buffer[d.head] = values[i]
+		d.length++
+	}
+}
+
+func (d *dequeImpl[V]) PeekFront() Result[V] {
+	if d.IsEmpty() {
+		return NewResultError[V]("Fatal error: trying to peek empty queue")
+	}
+	return NewResultFrom(d.buffer[d.head])
+}
+
+func (d *dequeImpl[V]) PeekBack() Result[V] {
+	if d.IsEmpty() {
+		return NewResultError[V]("Fatal error: trying to peek empty queue")
+	}
+	return NewResultFrom(d.buffer[d.bufferIndex(d.length-1)])
+}
+
+func (d *dequeImpl[V]) PopFront() Result[V] {
+	var value = d.PeekFront()
+	if value.Ok() {
+		var zero V
+		d.buffer[d.head] = zero // release references for the garbage collector
+		d.head = d.bufferIndex(1)
+		d.length--
+	}
+	return value
+}
+
+func (d *dequeImpl[V]) PopBack() Result[V] {
+	var value = d.PeekBack()
+	if value.Ok() {
+		var zero V
+		d.buffer[d.bufferIndex(d.length-1)] = zero
+		d.length--
+	}
+	return value
+}
+
+func (d *dequeImpl[V]) Push(values ...V)       { d.PushBack(values...) }
+func (d *dequeImpl[V]) PushArray(arr Array[V]) { d.PushBack(arr.SubSlice()...) }
+func (d *dequeImpl[V]) Pop() Result[V]         { return d.PopBack() }
+func (d *dequeImpl[V]) Peek() Result[V]        { return d.PeekBack() }
+
+func (d *dequeImpl[V]) Get(index int) Result[V] {
+	if !d.Has(index) {
+		return NewResultError[V]("Fatal error: Index out of bounds")
+	}
+	return NewResultFrom(d.buffer[d.bufferIndex(index)])
+}
+
+func (d *dequeImpl[V]) Put(index int, value V) bool {
+	if !d.Has(index) {
+		return false
+	}
+	d.buffer[d.bufferIndex(index)] = value
+	return true
+}
+
+// Removes the value at the index, the smaller side of the deque is moved
+func (d *dequeImpl[V]) Drop(index int) bool {
+	if !d.Has(index) {
+		return false
+	}
+	if index < d.length/2 {
+		for i := index; i > 0; i-- {
+			d.buffer[d.bufferIndex(i)] = d.buffer[d.bufferIndex(i-1)]
+		}
+		d.PopFront()
+	} else {
+		for i := index; i < d.length-1; i++ {
+			d.buffer[d.bufferIndex(i)] = d.buffer[d.bufferIndex(i+1)]
+		}
+		d.PopBack()
+	}
+	return true
+}
+
+// Shrinks the ring buffer to the number of values
+func (d *dequeImpl[V]) Compact() {
+	d.resize(d.length)
+}
+
+// Returns a copy of the values as go slice, changes to the slice do not affect the deque
+func (d *dequeImpl[V]) SubSlice(fromIndexToIndex ...int) []V {
+	var from, to = 0, d.length
+	switch len(fromIndexToIndex) {
+	case 0:
+	case 1:
+		from = fromIndexToIndex[0]
+	default:
+		from, to = fromIndexToIndex[0], fromIndexToIndex[1]
+	}
+	if from < 0 || to > d.length || from > to {
+		Throw("Fatal error: slice bounds out of range")
+	}
+	var slice = make([]V, to-from)
+	d.copyTo(slice, from, to)
+	return slice
+}
+
+func (d *dequeImpl[V]) NewIterator() Iterator[int, V] {
+	return &dequeIterator[V]{deque: d}
+}
+
+func (d *dequeImpl[V]) All() iter.Seq2[int, V] {
+	return Seq2[int, V](d)
+}
+
+func (d *dequeImpl[V]) Values() iter.Seq[V] {
+	return Seq[int, V](d)
+}
+
+type dequeIterator[V any] struct {
+	deque *dequeImpl[V]
+	index int
+}
+
+func (it *dequeIterator[V]) Ok() bool { return it.deque.Has(it.index) }
+func (it *dequeIterator[V]) Key() int { return it.index }
+func (it *dequeIterator[V]) Value() V { return it.deque.Get(it.index).Value() }
+func (it *dequeIterator[V]) Next()    { it.index++ }
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestQueue(t *testing.T) {
+	var q = sx.NewQueue[string]()
+	if !q.IsEmpty() || q.PeekFront().Ok() || q.PopFront().Ok() {
+		t.FailNow()
+	}
+	q.PushBack("a", "b")
+	q.PushBack("c")
+	if q.Length() != 3 || q.PeekFront().Value() != "a" {
+		t.FailNow()
+	}
+	if q.PopFront().Value() != "a" || q.PopFront().Value() != "b" || q.PopFront().Value() != "c" || !q.IsEmpty() {
+		t.FailNow()
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d = sx.NewDeque[int](2)
+	if d.PeekBack().Ok() || d.PopBack().Ok() || d.Peek().Ok() || d.Pop().Ok() {
+		t.FailNow()
+	}
+	d.PushBack(3, 4)
+	d.PushFront(1, 2)
+	d.Push(5)
+	d.PushArray(sx.NewArrayFrom(6, 7))
+	if !reflect.DeepEqual(d.SubSlice(), []int{1, 2, 3, 4, 5, 6, 7}) {
+		t.FailNow()
+	}
+	if d.PeekFront().Value() != 1 || d.PeekBack().Value() != 7 || d.Peek().Value() != 7 {
+		t.FailNow()
+	}
+	if d.PopBack().Value() != 7 || d.Pop().Value() != 6 || d.PopFront().Value() != 1 {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(d.SubSlice(), []int{2, 3, 4, 5}) || !reflect.DeepEqual(d.SubSlice(1), []int{3, 4, 5}) || !reflect.DeepEqual(d.SubSlice(1, 3), []int{3, 4}) {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(slices.Collect(sx.Seq(d)), []int{2, 3, 4, 5}) {
+		t.FailNow()
+	}
+	for k, v := range sx.Seq2(d) {
+		if d.Get(k).Value() != v {
+			t.FailNow()
+		}
+	}
+	defer sx.Catch(func(err error) {})
+	d.SubSlice(3, 5)
+	t.FailNow()
+}
+
+func TestDequeArrayInterface(t *testing.T) {
+	var d sx.Array[string] = sx.NewDequeFrom("a", "b", "c", "d", "e")
+	if !d.Has(0) || !d.Has(4) || d.Has(5) || d.Has(-1) {
+		t.FailNow()
+	}
+	if d.Get(1).Value() != "b" || d.Get(5).Ok() {
+		t.FailNow()
+	}
+	if !d.Put(1, "B") || d.Put(5, "x") || d.Get(1).Value() != "B" {
+		t.FailNow()
+	}
+	if !d.Drop(1) || !reflect.DeepEqual(d.SubSlice(), []string{"a", "c", "d", "e"}) {
+		t.FailNow()
+	}
+	if !d.Drop(2) || !reflect.DeepEqual(d.SubSlice(), []string{"a", "c", "e"}) {
+		t.FailNow()
+	}
+	if d.Drop(3) || d.Drop(-1) {
+		t.FailNow()
+	}
+	checkMapConformance[int, string](t, d, 3)
+
+	var visited = []string{}
+	for it := d.NewIterator(); it.Ok(); it.Next() {
+		visited = append(visited, it.Value())
+	}
+	if !reflect.DeepEqual(visited, []string{"a", "c", "e"}) {
+		t.FailNow()
+	}
+
+	sx.Sort(d, func(a, b string) bool { return a > b })
+	if !reflect.DeepEqual(d.SubSlice(), []string{"e", "c", "a"}) {
+		t.FailNow()
+	}
+}
+
+func TestDequeCompact(t *testing.T) {
+	var d = sx.NewDeque[int]()
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 98; i++ {
+		d.PopFront()
+	}
+	d.Compact()
+	if !reflect.DeepEqual(d.SubSlice(), []int{98, 99}) {
+		t.FailNow()
+	}
+	d.PushFront(97)
+	d.PopBack()
+	d.PopBack()
+	d.PopBack()
+	d.Compact()
+	if !d.IsEmpty() || len(d.SubSlice()) != 0 {
+		t.FailNow()
+	}
+	d.PushFront()
+	d.PushFront(1)
+	d.PushBack(make([]int, 20)...)
+	if d.PeekFront().Value() != 1 || d.Length() != 21 {
+		t.FailNow()
+	}
+}
+
+func TestDequeRandomized(t *testing.T) {
+	var random = rand.New(rand.NewSource(9))
+	var d = sx.NewDeque[int]()
+	var reference = []int{}
+	for i := 0; i < 10000; i++ {
+		switch random.Intn(6) {
+		case 0:
+			d.PushBack(i)
+			reference = append(reference, i)
+		case 1:
+			d.PushFront(i, i+1)
+			reference = append([]int{i, i + 1}, reference...)
+		case 2:
+			if d.PopFront().Ok() {
+				reference = reference[1:]
+			}
+		case 3:
+			if d.PopBack().Ok() {
+				reference = reference[:len(reference)-1]
+			}
+		case 4:
+			if len(reference) > 0 {
+				var index = random.Intn(len(reference))
+				d.Drop(index)
+				reference = slices.Delete(reference, index, index+1)
+			}
+		case 5:
+			if len(reference) > 0 {
+				var index = random.Intn(len(reference))
+				d.Put(index, -i)
+				reference[index] = -i
+			}
+		}
+		if d.Length() != len(reference) {
+			t.FailNow()
+		}
+	}
+	if !reflect.DeepEqual(d.SubSlice(), reference) {
+		t.FailNow()
+	}
+}
@@ -3,28 +3,167 @@ package sx
 
 import (
 	"errors"
+	"runtime"
 	"strings"
 )
 
+var _ error = &Error{}
+
+// Error with an optional cause, error code, key/value context and the stack of its creation
+//
+// Errors thrown by "Throw", "ThrowIf" and "ThrowWrap" are *sx.Error,
+// so "Catch" handlers can use errors.Is/errors.As and print a readable trace
+type Error struct {
+	message   string
+	cause     error
+	code      string
+	context   Map[string, any]
+	stack     []uintptr
+	recovered any // the original value of a recovered non-error panic
+}
+
+// Creates an error, the stack starts at the caller
+func NewError(messageParts ...string) *Error {
+	return newError(3, nil, messageParts...)
+}
+
+// Creates an error caused by another error, the stack starts at the caller
+func WrapError(cause error, messageParts ...string) *Error {
+	return newError(3, cause, messageParts...)
+}
+
+// 'skip' is the number of stack frames to leave out, see runtime.Callers
+func newError(skip int, cause error, messageParts ...string) *Error {
+	var stack = make([]uintptr, 32)
+	stack = stack[:runtime.Callers(skip, stack)]
+	return &Error{message: strings.Join(messageParts, " "), cause: cause, context: NewOrderedMap[string, any](), stack: stack}
+}
+
+// The message, followed by the message of the cause (if any)
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.message
+	case e.message == "":
+		return e.cause.Error()
+	}
+	return e.message + ": " + e.cause.Error()
+}
+
+// The message without the cause
+func (e *Error) Message() string {
+	return e.message
+}
+
+// The cause, or nil
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Sets the error code, returns the error itself for chaining
+func (e *Error) WithCode(code string) *Error {
+	e.code = code
+	return e
+}
+
+// The error code, empty if none was set
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Adds key/value context, returns the error itself for chaining
+func (e *Error) With(key string, value any) *Error {
+	e.context.Put(key, value)
+	return e
+}
+
+// The key/value context in the order it was added
+func (e *Error) Context() Map[string, any] {
+	return e.context
+}
+
+// The original value if the error was created from a non-error panic by "CatchAny", otherwise nil
+func (e *Error) PanicValue() any {
+	return e.recovered
+}
+
+// Supports errors.Is: errors with the same (non-empty) code are considered equal
+func (e *Error) Is(target error) bool {
+	var other, ok = target.(*Error)
+	return ok && e.code != "" && e.code == other.code
+}
+
+// The stack frames where the error was created, innermost first
+func (e *Error) Stack() []runtime.Frame {
+	var result = make([]runtime.Frame, 0, len(e.stack))
+	var frames = runtime.CallersFrames(e.stack)
+	for more := len(e.stack) > 0; more; {
+		var frame runtime.Frame
+		frame, more = frames.Next()
+		result = append(result, frame)
+	}
+	return result
+}
+
+// Prints the error, its code, context and stack, followed by the trace of its cause
+func (e *Error) Trace() string {
+	return ErrorTrace(e)
+}
+
+// Prints the whole causal chain of any error
+//
+// *sx.Error entries include their code, context and stack, other errors only their message
+func ErrorTrace(err error) string {
+	var sb = NewStringBuilder()
+	for prefix := "Error: "; err != nil; prefix = "Caused by: " {
+		var sxErr, ok = err.(*Error)
+		if !ok {
+			sb.WriteStrings(prefix, err.Error(), "\n")
+			err = errors.Unwrap(err)
+			continue
+		}
+		sb.WriteStrings(prefix, sxErr.message)
+		if sxErr.code != "" {
+			sb.WriteStrings(" [", sxErr.code, "]")
+		}
+		for it := sxErr.context.NewIterator(); it.Ok(); it.Next() {
+			sb.WriteAny(" ", it.Key(), "=", it.Value())
+		}
+		sb.WriteStrings("\n")
+		for _, frame := range sxErr.Stack() {
+			sb.WriteAny("    at ", frame.Function, " (", frame.File, ":", frame.Line, ")\n")
+		}
+		err = sxErr.cause
+	}
+	return sb.String()
+}
+
 // Throws an error by panic-ing
 func Throw(errorMessages ...string) {
-	var err error = errors.New(strings.Join(errorMessages, " "))
-	panic(err)
+	panic(newError(3, nil, errorMessages...))
 }
 
 // Throws an error by panic-ing iff the condition is true
 func ThrowIf(condition bool, errorMessages ...string) {
 	if condition {
-		Throw(errorMessages...)
+		panic(newError(3, nil, errorMessages...))
 	}
 }
 
+// Throws the error unchanged by panic-ing iff it is not nil
+//
+// Use ThrowWrap to record the stack of errors that are no *sx.Error
 func ThrowIfError(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
 
+// Throws an error caused by 'err' by panic-ing, the message is put in front of the cause's message
+func ThrowWrap(err error, errorMessages ...string) {
+	panic(newError(3, err, errorMessages...))
+}
+
 // Catches errors thrown by "Throw" or "ThrowIf", recovers the panic
 // Needs to be called before throwing errors
 // Only handles errors. If you throw/panic something else, you need to provide a custom recover function
@@ -40,3 +179,37 @@ func Catch(handler ...func(error)) {
 		}
 	}
 }
+
+// Catches only errors that match E (via errors.As), recovers the panic
+// Needs to be called with 'defer', other panics are thrown again
+func CatchAs[E error](handler func(err E)) {
+	if x := recover(); x != nil {
+		var target E
+		if err, ok := x.(error); ok && errors.As(err, &target) {
+			handler(target)
+			return
+		}
+		panic(x)
+	}
+}
+
+// Catches every panic, recovers it
+// Needs to be called with 'defer'. The handler always gets an error: panics that are no errors (e.g. panic("text"))
+// are converted to *sx.Error, the original value is available via PanicValue()
+func CatchAny(handler func(recovered any)) {
+	if x := recover(); x != nil {
+		var err, ok = x.(error)
+		if !ok {
+			var sxErr = newError(3, nil, Str(x))
+			sxErr.recovered = x
+			err = sxErr
+		}
+		handler(err)
+	}
+}
+
+// Calls the function and returns its value as a result, panics are caught and returned as error result
+func Try[T any](function func() T) (result Result[T]) {
+	defer CatchAny(func(err any) { result = NewResultFromError[T](err.(error)) })
+	return NewResultFrom(function())
+}
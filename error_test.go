@@ -3,6 +3,9 @@ package sx_test
 
 import (
 	"errors"
+	"io/fs"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -59,3 +62,142 @@ func TestCatch(t *testing.T) {
 	})
 	panic(2)
 }
+
+func TestThrowRecordsStack(t *testing.T) {
+	defer sx.Catch(func(err error) {
+		var sxErr *sx.Error
+		if !errors.As(err, &sxErr) || sxErr.Message() != "with stack" {
+			t.FailNow()
+		}
+		var stack = sxErr.Stack()
+		if len(stack) == 0 || !strings.HasSuffix(stack[0].Function, "TestThrowRecordsStack") {
+			t.FailNow()
+		}
+	})
+	sx.ThrowIf(true, "with", "stack")
+}
+
+func TestThrowWrap(t *testing.T) {
+	var cause = errors.New("file is missing")
+	defer sx.Catch(func(err error) {
+		if err.Error() != "cannot load config: file is missing" || !errors.Is(err, cause) {
+			t.FailNow()
+		}
+		if sxErr, ok := err.(*sx.Error); !ok || sxErr.Message() != "cannot load config" || sxErr.Unwrap() != cause {
+			t.FailNow()
+		}
+	})
+	sx.ThrowWrap(cause, "cannot load config")
+	t.FailNow()
+}
+
+func TestThrowIfErrorKeepsError(t *testing.T) {
+	defer sx.Catch(func(err error) {
+		if err != fs.ErrNotExist {
+			t.FailNow()
+		}
+	})
+	sx.ThrowIfError(fs.ErrNotExist)
+}
+
+func TestResultValueThrowsError(t *testing.T) {
+	var _, cause = strconv.Atoi("x")
+	defer sx.Catch(func(err error) {
+		if err != cause {
+			t.FailNow()
+		}
+	})
+	sx.NewResultFromError[int](cause).Value()
+	t.FailNow()
+}
+
+func TestErrorCodeAndContext(t *testing.T) {
+	var notFound = sx.NewError("not found").WithCode("E404")
+	var err = sx.WrapError(sx.NewError("no such user").WithCode("E404").With("user", "bob").With("id", 42), "request failed")
+	if !errors.Is(err, notFound) || errors.Is(err, sx.NewError("other").WithCode("E500")) || errors.Is(err, sx.NewError("no code")) {
+		t.FailNow()
+	}
+	var cause = err.Unwrap().(*sx.Error)
+	if cause.Code() != "E404" || err.Code() != "" || cause.Context().Get("id").Value() != 42 {
+		t.FailNow()
+	}
+
+	var trace = sx.ErrorTrace(sx.WrapError(err, "outer"))
+	var lines = strings.Split(trace, "\n")
+	if lines[0] != "Error: outer" || !strings.Contains(trace, "Caused by: request failed\n") || !strings.Contains(trace, "Caused by: no such user [E404] user=bob id=42\n") {
+		t.FailNow()
+	}
+	if !strings.HasPrefix(lines[1], "    at ") || !strings.Contains(lines[1], "TestErrorCodeAndContext") || !strings.Contains(lines[1], "error_test.go:") {
+		t.FailNow()
+	}
+	if trace := err.Trace(); !strings.HasPrefix(trace, "Error: request failed\n") {
+		t.FailNow()
+	}
+	if trace := sx.ErrorTrace(sx.WrapError(fs.ErrNotExist)); !strings.HasSuffix(trace, "Caused by: file does not exist\n") {
+		t.FailNow()
+	}
+}
+
+func TestCatchAs(t *testing.T) {
+	var caught *fs.PathError
+	func() {
+		defer sx.CatchAs(func(err *fs.PathError) { caught = err })
+		sx.ThrowWrap(&fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}, "cannot open")
+	}()
+	if caught == nil || caught.Path != "x" {
+		t.FailNow()
+	}
+}
+
+func TestCatchAsRethrows(t *testing.T) {
+	defer sx.Catch(func(err error) {
+		if err.Error() != "not a path error" {
+			t.FailNow()
+		}
+	})
+	defer sx.CatchAs(func(err *fs.PathError) { t.FailNow() })
+	sx.Throw("not a path error")
+}
+
+func TestCatchAsRethrowsNonErrors(t *testing.T) {
+	defer func() {
+		if x := recover(); x != 2 {
+			t.FailNow()
+		}
+	}()
+	defer sx.CatchAs(func(err *sx.Error) { t.FailNow() })
+	panic(2)
+}
+
+func TestCatchAny(t *testing.T) {
+	var catchValue = func(value any) (result error) {
+		defer sx.CatchAny(func(recovered any) { result = recovered.(error) })
+		panic(value)
+	}
+	var err = catchValue("text").(*sx.Error)
+	if err.Error() != "text" || err.PanicValue() != "text" || !strings.Contains(err.Trace(), "TestCatchAny") {
+		t.FailNow()
+	}
+	if err := catchValue(42).(*sx.Error); err.Error() != "42" || err.PanicValue() != 42 {
+		t.FailNow()
+	}
+	if err := catchValue(fs.ErrExist); err != fs.ErrExist {
+		t.FailNow()
+	}
+	if sx.NewError("no panic").PanicValue() != nil {
+		t.FailNow()
+	}
+}
+
+func TestTry(t *testing.T) {
+	if r := sx.Try(func() int { return 42 }); r.Value() != 42 {
+		t.FailNow()
+	}
+	if r := sx.Try(func() int { sx.Throw("failed"); return 42 }); r.Error() != "failed" {
+		t.FailNow()
+	}
+	var m map[string]*int
+	if r := sx.Try(func() int { return *m["x"] }); r.Ok() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"errors"
+	"strconv"
+)
+
+var _ error = &ErrorList{}
+var _ Container = &ErrorList{}
+
+// Collects errors of batch operations, so all failures can be reported instead of only the first
+//
+// errors.Is/errors.As search all collected errors. The zero value is an empty list, ready to use
+type ErrorList struct {
+	errs []error
+}
+
+// Creates an error list, nil errors are ignored
+func NewErrorList(errs ...error) *ErrorList {
+	return (&ErrorList{}).Add(errs...)
+}
+
+// Adds errors, nil errors (and nil error lists) are ignored and the errors of other error lists are added one by one
+func (l *ErrorList) Add(errs ...error) *ErrorList {
+	for _, err := range errs {
+		if other, ok := err.(*ErrorList); ok {
+			if other != nil {
+				l.errs = append(l.errs, other.errs...)
+			}
+		} else if err != nil {
+			l.errs = append(l.errs, err)
+		}
+	}
+	return l
+}
+
+// Adds the error of a failed sx.Result, valid results are ignored
+func (l *ErrorList) AddResult(result interface{ Err() error }) *ErrorList {
+	return l.Add(result.Err())
+}
+
+func (l *ErrorList) Length() int {
+	return len(l.errs)
+}
+
+func (l *ErrorList) IsEmpty() bool {
+	return l.Length() == 0
+}
+
+// The collected errors in the order they were added
+func (l *ErrorList) Errors() Array[error] {
+	return NewArrayFrom(l.errs...)
+}
+
+// Returns nil if the list is empty, otherwise the list itself, e.g. for ThrowIfError(list.Err())
+func (l *ErrorList) Err() error {
+	if l.IsEmpty() {
+		return nil
+	}
+	return l
+}
+
+// Converts to an error created by errors.Join, nil if the list is empty
+func (l *ErrorList) Join() error {
+	return errors.Join(l.errs...)
+}
+
+// Numbered summary of all errors, one per line
+func (l *ErrorList) Error() string {
+	switch len(l.errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l.errs[0].Error()
+	}
+	var sb = NewStringBuilder()
+	sb.WriteStrings(strconv.Itoa(len(l.errs)), " errors occurred:")
+	for i, err := range l.errs {
+		sb.WriteStrings("\n  ", strconv.Itoa(i+1), ". ", err.Error())
+	}
+	return sb.String()
+}
+
+// Supports errors.Is/errors.As
+func (l *ErrorList) Unwrap() []error {
+	return l.errs
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestErrorList(t *testing.T) {
+	var list sx.ErrorList
+	if !list.IsEmpty() || list.Err() != nil || list.Join() != nil || list.Error() != "no errors" {
+		t.FailNow()
+	}
+
+	list.Add(nil, errors.New("first"))
+	list.AddResult(sx.NewResultFrom(1))
+	if list.Length() != 1 || list.Error() != "first" || list.Err() != &list {
+		t.FailNow()
+	}
+
+	list.AddResult(sx.NewResultFromError[int](fs.ErrNotExist))
+	list.Add(sx.NewErrorList(errors.New("third"), nil, errors.New("fourth")))
+	list.Add((*sx.ErrorList)(nil))
+	if sx.NewErrorList((*sx.ErrorList)(nil)).Err() != nil {
+		t.FailNow()
+	}
+	if list.Length() != 4 || list.Errors().Get(3).Value().Error() != "fourth" {
+		t.FailNow()
+	}
+	if list.Error() != "4 errors occurred:\n  1. first\n  2. file does not exist\n  3. third\n  4. fourth" {
+		t.FailNow()
+	}
+	if !errors.Is(&list, fs.ErrNotExist) || errors.Is(&list, fs.ErrExist) || !errors.Is(list.Join(), fs.ErrNotExist) {
+		t.FailNow()
+	}
+}
+
+func TestThrowErrorList(t *testing.T) {
+	var list = sx.NewErrorList(sx.NewError("bad input").WithCode("E400"))
+	defer sx.Catch(func(err error) {
+		var errList *sx.ErrorList
+		if !errors.As(err, &errList) || errList.Length() != 1 || !errors.Is(err, sx.NewError("").WithCode("E400")) {
+			t.FailNow()
+		}
+	})
+	sx.ThrowIfError(sx.NewErrorList().Err())
+	sx.ThrowIfError(list.Err())
+	t.FailNow()
+}
@@ -10,6 +10,7 @@ import (
 func TestExamples(t *testing.T) {
 	base()
 	arraysMaps()
+	filesDirs()
 	if playThrowAndCatch(true).Ok() {
 		t.FailNow()
 	}
@@ -34,6 +35,11 @@ func arraysMaps() {
 		sx.PrintAnyLn("Key: '", it.Key(), "' ; Value: '", it.Value(), "'")
 	}
 
+	// the same, using a range loop. All() works for every array and map
+	for key, value := range sx.Seq2(array1) {
+		sx.PrintAnyLn("Key: '", key, "' ; Value: '", value, "'")
+	}
+
 	// arrays are maps - they satisfy the 'Map' interface
 	var myMap sx.Map[int, int] = array1
 
@@ -52,6 +58,15 @@ func arraysMaps() {
 	}
 }
 
+func filesDirs() {
+	// directories use the os file system by default, tests can use a file system in memory instead
+	// derived directories and files keep the file system of their parent
+	var dir = sx.NewDir("", "home", "sx").WithFileSystem(sx.NewMemoryFileSystem())
+	var config = dir.CreateDir("config").Value()
+	config.WriteAllText("app.json", "{}")
+	sx.PrintLn(config.ReadAllText("app.json").Value())
+}
+
 // this function returns a result which contains either the value (of type int) or an error
 func playThrowAndCatch(throws bool) (result sx.Result[int]) {
 
@@ -34,16 +34,41 @@ func DirUserHome() Result[Dir] {
 	return NewResultFrom(NewDirFromString(userHomeDir))
 }
 
-// Returns a Dir from a string, creates a new directory if needed (including all parents)
-func MkDirAndPath(fullPathString string) Result[Dir] {
-	var err = os.MkdirAll(fullPathString, 700)
+// Permissions for created files and directories, zero values mean the defaults (0644 for files, 0755 for directories)
+//
+// As usual, the umask of the process applies, except for the atomic writes, which set the file mode exactly
+type FileOptions struct {
+	FileMode fs.FileMode
+	DirMode  fs.FileMode
+}
+
+func fileOptions(options []FileOptions) FileOptions {
+	var result = FileOptions{FileMode: 0644, DirMode: 0755}
+	if len(options) > 0 && options[0].FileMode != 0 {
+		result.FileMode = options[0].FileMode
+	}
+	if len(options) > 0 && options[0].DirMode != 0 {
+		result.DirMode = options[0].DirMode
+	}
+	return result
+}
+
+// Returns a Dir from a string, creates a new directory on the os file system if needed (including all parents)
+//
+// Use Dir.CreateDir for other file systems
+func MkDirAndPath(fullPathString string, options ...FileOptions) Result[Dir] {
+	var err = os.MkdirAll(fullPathString, fileOptions(options).DirMode)
 	if err != nil {
 		return NewResultFromError[Dir](err)
 	}
 	return NewResultFrom(NewDirFromString(fullPathString))
 }
 
-type Dir string
+// A directory on a file system, the os file system by default (see WithFileSystem)
+type Dir struct {
+	path string
+	fsys FileSystem // nil means the os file system
+}
 
 // Create a Dir from a path/string
 // Directories always end with the FilePathSeparator
@@ -55,15 +80,37 @@ func NewDirFromString(path string) Dir {
 	if RunningOnWindows {
 		path = strings.ReplaceAll(path, `/`, `\`)
 	}
-	return Dir(path).normalize()
+	return Dir{path: path}.normalize()
 }
 
 func (dir Dir) normalize() Dir {
-	var path = string(dir)
-	if !strings.HasSuffix(path, FilePathSeparator) {
-		path = StrCat(path, FilePathSeparator)
+	if !strings.HasSuffix(dir.path, FilePathSeparator) {
+		dir.path = StrCat(dir.path, FilePathSeparator)
+	}
+	return dir
+}
+
+// A Dir from a string on the same file system
+func (dir Dir) at(path string) Dir {
+	var result = NewDirFromString(path)
+	result.fsys = dir.fsys
+	return result
+}
+
+// The same directory on another file system, e.g. a MemoryFileSystem in tests
+//
+// Directories and files derived from the result (e.g. by CreateDir, Cd, Parent or File) use the same file system
+func (dir Dir) WithFileSystem(fsys FileSystem) Dir {
+	dir.fsys = fsys
+	return dir
+}
+
+// The file system of the directory, the os file system by default
+func (dir Dir) FileSystem() FileSystem {
+	if dir.fsys == nil {
+		return osFileSystem{}
 	}
-	return Dir(path)
+	return dir.fsys
 }
 
 // Create a Dir from its parts
@@ -78,36 +125,47 @@ func NewDir(pathParts ...string) Dir {
 }
 
 func (dir Dir) String() string {
-	return string(dir)
+	return dir.path
+}
+
+// Encodes the path as text (e.g. a json string), the file system is not part of it
+func (dir Dir) MarshalText() ([]byte, error) {
+	return []byte(dir.path), nil
+}
+
+// Decodes the path from text, the result is on the os file system
+func (dir *Dir) UnmarshalText(text []byte) error {
+	*dir = NewDirFromString(string(text))
+	return nil
 }
 
 func (dir Dir) Exists() bool {
-	fileInfo, err := os.Stat(dir.String())
+	fileInfo, err := dir.FileSystem().Stat(dir.String())
 	return err == nil && fileInfo.Mode().IsDir()
 }
 
 func (dir Dir) IsFile(fileName string) bool {
 	var fullName = StrCat(dir.String(), fileName)
-	fileInfo, err := os.Stat(fullName)
+	fileInfo, err := dir.FileSystem().Stat(fullName)
 	return err == nil && fileInfo.Mode().IsRegular()
 }
 
 func (dir Dir) IsDirectory(fileName string) bool {
 	var fullName = StrCat(dir.String(), fileName)
-	fileInfo, err := os.Stat(fullName)
+	fileInfo, err := dir.FileSystem().Stat(fullName)
 	return err == nil && fileInfo.Mode().IsDir()
 }
 
 func (dir Dir) IsSymlink(fileName string) bool {
 	var fullName = StrCat(dir.String(), fileName)
-	fileInfo, err := os.Stat(fullName)
+	fileInfo, err := dir.FileSystem().Stat(fullName)
 	return err == nil && (fileInfo.Mode()&os.ModeSymlink) != 0
 }
 
-func (dir Dir) CreateDir(folderName string) Result[Dir] {
+func (dir Dir) CreateDir(folderName string, options ...FileOptions) Result[Dir] {
 	var newDirName = StrCat(dir.String(), folderName)
-	var newDir = NewDirFromString(newDirName).normalize()
-	var err = os.MkdirAll(newDir.String(), 700)
+	var newDir = dir.at(newDirName)
+	var err = dir.FileSystem().MkdirAll(newDir.String(), fileOptions(options).DirMode)
 	if err != nil {
 		return NewResultFromError[Dir](err)
 	}
@@ -115,7 +173,7 @@ func (dir Dir) CreateDir(folderName string) Result[Dir] {
 }
 
 func (dir Dir) ReadAllBytes(fromFileName string) Result[[]byte] {
-	var content, err = os.ReadFile(StrCat(dir.String(), fromFileName))
+	var content, err = fsReadFile(dir.FileSystem(), StrCat(dir.String(), fromFileName))
 	if err != nil {
 		return NewResultFromError[[]byte](err)
 	}
@@ -126,7 +184,7 @@ func (dir Dir) ReadAllBytes(fromFileName string) Result[[]byte] {
 func (dir Dir) ReadAllText(fromFileName string) Result[string] {
 	var bytes = dir.ReadAllBytes(fromFileName)
 	if !bytes.Ok() {
-		return NewResultError[string](bytes.Error())
+		return NewResultFromError[string](bytes.Err())
 	}
 	var text = string(bytes.Value())
 	if RunningOnWindows {
@@ -136,9 +194,67 @@ func (dir Dir) ReadAllText(fromFileName string) Result[string] {
 }
 
 // Write all the text to a file, no modifications
-func (dir Dir) WriteAllText(toFileName string, text string) error {
+func (dir Dir) WriteAllText(toFileName string, text string, options ...FileOptions) error {
+	return dir.WriteAllBytes(toFileName, []byte(text), options...)
+}
+
+// Write all the bytes to a file, the mode is only used if the file is created
+func (dir Dir) WriteAllBytes(toFileName string, content []byte, options ...FileOptions) error {
 	var fullFileName = StrCat(dir.String(), toFileName)
-	return os.WriteFile(fullFileName, []byte(text), fs.ModePerm)
+	return fsWriteFile(dir.FileSystem(), fullFileName, content, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileOptions(options).FileMode)
+}
+
+// Write all the text to a file, readers see either the old or the new content, but never a part of it
+//
+// See WriteAllBytesAtomic
+func (dir Dir) WriteAllTextAtomic(toFileName string, text string, options ...FileOptions) error {
+	return dir.WriteAllBytesAtomic(toFileName, []byte(text), options...)
+}
+
+// Write all the bytes to a file, readers see either the old or the new content, but never a part of it
+//
+// The content is written to a temporary file in the same directory, synced to disk and renamed to the target.
+// Finally the directory is synced, so the rename survives a crash.
+// The file mode is set exactly. Without a FileMode option, a replaced file keeps its permissions and new files get 0644
+func (dir Dir) WriteAllBytesAtomic(toFileName string, content []byte, options ...FileOptions) error {
+	var fullFileName = StrCat(dir.String(), toFileName)
+	var targetDir = filepath.Dir(fullFileName)
+	var fsys = dir.FileSystem()
+	var mode = fileOptions(options).FileMode
+	if info, err := fsys.Stat(fullFileName); err == nil && (len(options) == 0 || options[0].FileMode == 0) {
+		mode = info.Mode().Perm()
+	}
+	var temp, err = fsys.CreateTemp(targetDir, StrCat(".", filepath.Base(fullFileName), ".*.tmp"))
+	if err != nil {
+		return err
+	}
+	defer fsys.Remove(temp.Name()) // fails silently after the rename
+	_, err = temp.Write(content)
+	if err == nil {
+		err = temp.Chmod(mode)
+	}
+	if err == nil {
+		err = temp.Sync()
+	}
+	if err = NewErrorList(err, temp.Close()).Err(); err != nil {
+		return err
+	}
+	if err = fsys.Rename(temp.Name(), fullFileName); err != nil {
+		return err
+	}
+	return syncDir(fsys, targetDir)
+}
+
+// Directories cannot be synced on windows, renames are durable there anyway
+func syncDir(fsys FileSystem, path string) error {
+	if RunningOnWindows {
+		return nil
+	}
+	var dir, err = fsys.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	return NewErrorList(dir.Sync(), dir.Close()).Err()
 }
 
 // Descends into a folder. Folder must exist.
@@ -147,8 +263,7 @@ func (dir Dir) Cd(folderName string) Result[Dir] {
 		return NewResultError[Dir](ReflectFunctionName(), ": cannot change directory, because directory '", folderName, "' does not exist in '", dir.String(), "'")
 	}
 	var newDirName = StrCat(dir.String(), folderName)
-	var newDir = NewDirFromString(newDirName).normalize()
-	return NewResultFrom(newDir)
+	return NewResultFrom(dir.at(newDirName))
 }
 
 func (dir Dir) HasParent() bool {
@@ -157,7 +272,7 @@ func (dir Dir) HasParent() bool {
 }
 func (dir Dir) Parent() Result[Dir] {
 	var parentDirString = filepath.Dir(dir.String())
-	var parent = NewDirFromString(parentDirString)
+	var parent = dir.at(parentDirString)
 	return NewResultFrom(parent)
 }
 
@@ -178,7 +293,7 @@ func FileWithoutExtension(fileNameOrPath string) string {
 
 func (dir Dir) NewIterator() Iterator[int, string] {
 	var entries = NewArray[string]()
-	var files, _ = os.ReadDir(dir.String())
+	var files, _ = dir.FileSystem().ReadDir(dir.String())
 	for _, file := range files {
 		entries.Push(file.Name())
 	}
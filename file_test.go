@@ -3,6 +3,7 @@ package sx_test
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -10,7 +11,7 @@ import (
 
 func TestFileNew(t *testing.T) {
 	var tempDir = sx.DirTemp()
-	if !tempDir.Exists() {
+	if tempDir != sx.NewDirFromString(os.TempDir()) {
 		t.FailNow()
 	}
 	var emptyDir = sx.NewDirFromString("")
@@ -18,8 +19,13 @@ func TestFileNew(t *testing.T) {
 		t.FailNow()
 	}
 
-	var dir = sx.DirUserHome().Value()
-	if !dir.Exists() {
+	var memory = sx.NewMemoryFileSystem()
+	var home = sx.NewDir("", "home", "sx").WithFileSystem(memory)
+	if home.Exists() {
+		t.FailNow()
+	}
+	var dir = home.CreateDir("").Value()
+	if !dir.Exists() || dir != home {
 		t.FailNow()
 	}
 	var newTempDir = ".sx_test"
@@ -39,7 +45,7 @@ func TestFileNew(t *testing.T) {
 	if dir3.String() != dir2.String() {
 		t.FailNow()
 	}
-	if !dir.HasParent() || dir.Parent().Value() != sx.DirUserHome().Value() {
+	if !dir.HasParent() || dir.Parent().Value() != home {
 		t.FailNow()
 	}
 	result = dir.Cd("_folder_that_does_not_exist")
@@ -63,8 +69,8 @@ func TestFileNew(t *testing.T) {
 		t.FailNow()
 	}
 
-	var failedDir = sx.NewDirFromString("/::")
-	if e := failedDir.CreateDir("this fails, because dir cannot exist"); e.Ok() {
+	// a file blocks the path, so the directory cannot exist
+	if e := dir.CreateDir(testFileName + "/this fails, because dir cannot exist"); e.Ok() {
 		t.FailNow()
 	}
 
@@ -85,7 +91,7 @@ func TestFileNew(t *testing.T) {
 }
 
 func TestFailedFiles(t *testing.T) {
-	var dir = sx.DirUserHome().Value()
+	var dir = sx.NewDir("", "home", "sx").WithFileSystem(sx.NewMemoryFileSystem())
 	if dir.IsSymlink("_link_that_does_not_exist") {
 		t.FailNow()
 	}
@@ -101,9 +107,9 @@ func TestFailedFiles(t *testing.T) {
 }
 
 func TestTemp(t *testing.T) {
-	var mp = sx.DirTemp()
-	var dir = sx.MkDirAndPath(mp.String() + "/.sx_test/someFolder")
-	if !dir.Ok() {
+	var mp = sx.DirTemp().WithFileSystem(sx.NewMemoryFileSystem())
+	var dir = mp.CreateDir(".sx_test/someFolder")
+	if !dir.Ok() || !mp.IsDirectory(".sx_test") {
 		t.FailNow()
 	}
 	var failed = sx.MkDirAndPath("NOTEXISTING:/.sx_test")
@@ -152,3 +158,89 @@ func TestFileBaseAndExtension(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestFilePermissions(t *testing.T) {
+	if sx.RunningOnWindows {
+		t.Skip()
+	}
+	var dir = sx.NewDirFromString(t.TempDir())
+	var perm = func(name string) os.FileMode {
+		var info, err = os.Stat(dir.String() + name)
+		sx.ThrowIfError(err)
+		return info.Mode().Perm()
+	}
+	// the umask may only remove permissions
+	dir.CreateDir("default")
+	dir.CreateDir("private", sx.FileOptions{DirMode: 0700})
+	sx.MkDirAndPath(dir.String()+"a/b", sx.FileOptions{DirMode: 0750})
+	dir.WriteAllText("default.txt", "x")
+	dir.WriteAllBytes("private.txt", []byte("x"), sx.FileOptions{FileMode: 0600})
+	if perm("default")&^0755 != 0 || perm("default")&0700 != 0700 || perm("private") != 0700 || perm("a/b")&^0750 != 0 {
+		t.FailNow()
+	}
+	if perm("default.txt")&^0644 != 0 || perm("default.txt")&0600 != 0600 || perm("private.txt") != 0600 {
+		t.FailNow()
+	}
+
+	// atomic writes set the mode exactly, replaced files keep their mode unless one is given
+	sx.ThrowIfError(dir.WriteAllTextAtomic("atomic.txt", "first"))
+	if perm("atomic.txt") != 0644 || dir.ReadAllText("atomic.txt").Value() != "first" {
+		t.FailNow()
+	}
+	sx.ThrowIfError(dir.WriteAllBytesAtomic("atomic.txt", []byte("second"), sx.FileOptions{FileMode: 0640}))
+	if perm("atomic.txt") != 0640 || dir.ReadAllText("atomic.txt").Value() != "second" {
+		t.FailNow()
+	}
+	sx.ThrowIfError(dir.WriteAllTextAtomic("private.txt", "secret"))
+	sx.ThrowIfError(dir.WriteAllTextAtomic("atomic.txt", "third", sx.FileOptions{DirMode: 0700}))
+	if perm("private.txt") != 0600 || perm("atomic.txt") != 0640 || dir.ReadAllText("private.txt").Value() != "secret" {
+		t.FailNow()
+	}
+	sx.ThrowIfError(dir.File("handle.txt").WriteAllTextAtomic("x", sx.FileOptions{FileMode: 0604}))
+	sx.ThrowIfError(dir.File("bytes.txt").WriteAllBytes([]byte("x")))
+	sx.ThrowIfError(dir.File("bytes.txt").WriteAllBytesAtomic([]byte("y")))
+	if perm("handle.txt") != 0604 || dir.ReadAllText("bytes.txt").Value() != "y" {
+		t.FailNow()
+	}
+
+	// no temporary files are left behind
+	var names = sx.CollectArray(dir.NewIterator()).SubSlice()
+	if len(names) != 8 {
+		t.Fatal(names)
+	}
+}
+
+func TestAtomicWriteErrors(t *testing.T) {
+	var dir = sx.NewDirFromString(t.TempDir())
+	if dir.WriteAllTextAtomic("missing/file.txt", "x") == nil {
+		t.FailNow()
+	}
+	dir.CreateDir("target")
+	if dir.WriteAllTextAtomic("target", "x") == nil {
+		t.FailNow()
+	}
+}
+
+func TestAtomicWriteIsNeverPartial(t *testing.T) {
+	var dir = sx.NewDirFromString(t.TempDir())
+	var contents = []string{strings.Repeat("a", 1<<20), strings.Repeat("b", 1<<19)}
+	sx.ThrowIfError(dir.WriteAllTextAtomic("data", contents[0]))
+	var done = make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			sx.ThrowIfError(dir.WriteAllTextAtomic("data", contents[i%2]))
+		}
+	}()
+	for reading := true; reading; {
+		select {
+		case <-done:
+			reading = false
+		default:
+		}
+		var text = dir.ReadAllText("data").Value()
+		if text != contents[0] && text != contents[1] {
+			t.FailNow()
+		}
+	}
+}
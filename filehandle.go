@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// A file in a directory, the file does not need to exist
+type File struct {
+	Dir  Dir
+	Name string
+}
+
+// Creates a File from a full path
+func NewFileFromString(path string) File {
+	return File{Dir: NewDirFromString(filepath.Dir(path)), Name: filepath.Base(path)}
+}
+
+// The file with this name in the directory
+func (dir Dir) File(fileName string) File {
+	return File{Dir: dir, Name: fileName}
+}
+
+// Iterates the files (or symlinks to files) in the directory, keyed and sorted by name
+func (dir Dir) Files() Iterator[string, File] {
+	var files = NewOrderedMap[string, File]()
+	for it := dir.NewIterator(); it.Ok(); it.Next() {
+		if dir.IsFile(it.Value()) {
+			files.Put(it.Value(), dir.File(it.Value()))
+		}
+	}
+	return files.NewIterator()
+}
+
+// Iterates the sub directories (or symlinks to directories) in the directory, keyed and sorted by name
+func (dir Dir) Dirs() Iterator[string, Dir] {
+	var dirs = NewOrderedMap[string, Dir]()
+	for it := dir.NewIterator(); it.Ok(); it.Next() {
+		if dir.IsDirectory(it.Value()) {
+			dirs.Put(it.Value(), dir.at(StrCat(dir.String(), it.Value())))
+		}
+	}
+	return dirs.NewIterator()
+}
+
+// The full path
+func (file File) String() string {
+	return StrCat(file.Dir.String(), file.Name)
+}
+
+// Checks if the file exists and is a regular file
+func (file File) Exists() bool {
+	return file.Dir.IsFile(file.Name)
+}
+
+func (file File) Size() Result[int64] {
+	return ResultMap(file.stat(), fs.FileInfo.Size)
+}
+
+func (file File) ModTime() Result[time.Time] {
+	return ResultMap(file.stat(), fs.FileInfo.ModTime)
+}
+
+func (file File) Mode() Result[fs.FileMode] {
+	return ResultMap(file.stat(), fs.FileInfo.Mode)
+}
+
+func (file File) stat() Result[fs.FileInfo] {
+	return NewResultFromTuple(file.Dir.FileSystem().Stat(file.String()))
+}
+
+// The extension including the dot, e.g. ".txt", see FileExtension
+func (file File) Extension() string {
+	return FileExtension(file.Name)
+}
+
+// The name without its directory, see FileBaseName
+func (file File) BaseName() string {
+	return FileBaseName(file.Name)
+}
+
+// The name without directory and extension, see FileWithoutExtension
+func (file File) NameWithoutExtension() string {
+	return FileWithoutExtension(file.Name)
+}
+
+func (file File) ReadAllBytes() Result[[]byte] {
+	return file.Dir.ReadAllBytes(file.Name)
+}
+
+// Reads all the text from the file, see Dir.ReadAllText
+func (file File) ReadAllText() Result[string] {
+	return file.Dir.ReadAllText(file.Name)
+}
+
+// Write all the text to the file, no modifications
+func (file File) WriteAllText(text string, options ...FileOptions) error {
+	return file.Dir.WriteAllText(file.Name, text, options...)
+}
+
+// Write all the bytes to the file, see Dir.WriteAllBytes
+func (file File) WriteAllBytes(content []byte, options ...FileOptions) error {
+	return file.Dir.WriteAllBytes(file.Name, content, options...)
+}
+
+// Write all the text to the file atomically, see Dir.WriteAllBytesAtomic
+func (file File) WriteAllTextAtomic(text string, options ...FileOptions) error {
+	return file.Dir.WriteAllTextAtomic(file.Name, text, options...)
+}
+
+// Write all the bytes to the file atomically, see Dir.WriteAllBytesAtomic
+func (file File) WriteAllBytesAtomic(content []byte, options ...FileOptions) error {
+	return file.Dir.WriteAllBytesAtomic(file.Name, content, options...)
+}
+
+// Appends the text to the file, creates the file if needed
+func (file File) Append(text string, options ...FileOptions) error {
+	return fsWriteFile(file.Dir.FileSystem(), file.String(), []byte(text), os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileOptions(options).FileMode)
+}
+
+// Compares the paths and, for links or different spellings of the same path, the file identity
+func (file File) isSameFile(other File, info fs.FileInfo) bool {
+	if file.Dir.FileSystem() != other.Dir.FileSystem() {
+		return false
+	}
+	if filepath.Clean(file.String()) == filepath.Clean(other.String()) {
+		return true
+	}
+	var otherInfo = other.stat()
+	return otherInfo.Ok() && os.SameFile(info, otherInfo.Value())
+}
+
+// Renames the file within its directory, returns the renamed file
+func (file File) Rename(newName string) Result[File] {
+	var renamed = file.Dir.File(newName)
+	var err = file.Dir.FileSystem().Rename(file.String(), renamed.String())
+	if err != nil {
+		return NewResultFromError[File](err)
+	}
+	return NewResultFrom(renamed)
+}
+
+func (file File) Remove() error {
+	return file.Dir.FileSystem().Remove(file.String())
+}
+
+// Copies the content and permissions to the target file, an existing target is overwritten
+//
+// The target may be on another file system. Copying a file onto itself fails, the content would be lost otherwise
+func (file File) CopyTo(target File) Result[File] {
+	var source, err = file.Dir.FileSystem().OpenFile(file.String(), os.O_RDONLY, 0)
+	if err != nil {
+		return NewResultFromError[File](err)
+	}
+	defer source.Close()
+	var info = file.stat()
+	if !info.Ok() {
+		return NewResultFromError[File](info.Err())
+	}
+	if file.isSameFile(target, info.Value()) {
+		return NewResultError[File]("Fatal error: cannot copy '", file.String(), "' onto itself")
+	}
+	destination, err := target.Dir.FileSystem().OpenFile(target.String(), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Value().Mode().Perm())
+	if err != nil {
+		return NewResultFromError[File](err)
+	}
+	_, err = io.Copy(destination, source)
+	if err = NewErrorList(err, destination.Close()).Err(); err != nil {
+		return NewResultFromError[File](err)
+	}
+	return NewResultFrom(target)
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestFileHandle(t *testing.T) {
+	var dir = sx.NewDirFromString(t.TempDir())
+	var file = dir.File("notes.txt")
+	if file.Exists() || file.Size().Ok() || file.ModTime().Ok() || file.Mode().Ok() || file.ReadAllText().Ok() {
+		t.FailNow()
+	}
+	if file.String() != dir.String()+"notes.txt" || file.Extension() != ".txt" || file.BaseName() != "notes.txt" || file.NameWithoutExtension() != "notes" {
+		t.FailNow()
+	}
+
+	sx.ThrowIfError(file.WriteAllText("hello"))
+	sx.ThrowIfError(file.Append(" world"))
+	if !file.Exists() || file.ReadAllText().Value() != "hello world" || string(file.ReadAllBytes().Value()) != "hello world" {
+		t.FailNow()
+	}
+	if file.Size().Value() != 11 || time.Since(file.ModTime().Value()) > time.Minute || !file.Mode().Value().IsRegular() {
+		t.FailNow()
+	}
+
+	var renamed = file.Rename("renamed.md").Value()
+	if file.Exists() || !renamed.Exists() || renamed.Name != "renamed.md" || renamed.Dir != dir {
+		t.FailNow()
+	}
+	if file.Rename("other").Ok() || !errors.Is(file.Remove(), fs.ErrNotExist) {
+		t.FailNow()
+	}
+
+	var copied = renamed.CopyTo(dir.File("copy.md")).Value()
+	if copied.ReadAllText().Value() != "hello world" || !renamed.Exists() {
+		t.FailNow()
+	}
+	if file.CopyTo(dir.File("x")).Ok() || renamed.CopyTo(dir.File("missing/x")).Ok() {
+		t.FailNow()
+	}
+	sx.ThrowIfError(renamed.Remove())
+	if renamed.Exists() || dir.File("missing/x").Append("x") == nil {
+		t.FailNow()
+	}
+}
+
+func TestNewFileFromString(t *testing.T) {
+	var path = filepath.Join(os.TempDir(), "a", "b.txt")
+	var file = sx.NewFileFromString(path)
+	if file.Name != "b.txt" || file.Dir != sx.NewDirFromString(filepath.Join(os.TempDir(), "a")) || file.String() != path {
+		t.FailNow()
+	}
+}
+
+func TestDirFilesAndDirs(t *testing.T) {
+	var dir = newWalkTestDir(t, "b.txt", "a.txt", "sub2/x", "sub1/")
+	var files = sx.CollectArray(sx.MapIter(dir.Files(), func(name string, file sx.File) string { return name + ":" + file.ReadAllText().Value() }))
+	if !reflect.DeepEqual(files.SubSlice(), []string{"a.txt:a.txt", "b.txt:b.txt"}) {
+		t.FailNow()
+	}
+	var dirs = []string{}
+	for it := dir.Dirs(); it.Ok(); it.Next() {
+		if !it.Value().Exists() || it.Value() != dir.Cd(it.Key()).Value() {
+			t.FailNow()
+		}
+		dirs = append(dirs, it.Key())
+	}
+	if !reflect.DeepEqual(dirs, []string{"sub1", "sub2"}) {
+		t.FailNow()
+	}
+}
+
+func TestFileCopyToItself(t *testing.T) {
+	var dir = sx.NewDirFromString(t.TempDir())
+	var file = dir.File("data.txt")
+	sx.ThrowIfError(file.WriteAllText("precious"))
+	sx.ThrowIfError(os.Symlink(file.String(), dir.File("link.txt").String()))
+	var sameFiles = []sx.File{file, dir.CreateDir("sub").Value().File("../data.txt"), dir.File("link.txt")}
+	for _, target := range sameFiles {
+		if result := file.CopyTo(target); result.Ok() || !strings.Contains(result.Error(), "onto itself") {
+			t.Fatal(target)
+		}
+	}
+	if file.ReadAllText().Value() != "precious" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var _ FileSystem = osFileSystem{}
+var _ FileSystem = &readOnlyFileSystem{}
+var _ FileSystemFile = &os.File{}
+var _ FileSystemFile = &readOnlyFile{}
+
+// The file operations behind Dir and File, see Dir.WithFileSystem
+//
+// The names are full paths as built by Dir, the errors should be *fs.PathError like the ones of the os package.
+// Implementations must be comparable (e.g. pointers), because Dir values are compared
+type FileSystem interface {
+	OpenFile(name string, flag int, perm fs.FileMode) (FileSystemFile, error)
+	CreateTemp(dir string, pattern string) (FileSystemFile, error) // like os.CreateTemp, the file name is the full path
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error) // sorted by name
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	Rename(oldPath string, newPath string) error
+}
+
+// An open file of a FileSystem, *os.File implements it
+type FileSystemFile interface {
+	io.ReadWriteCloser
+	Name() string
+	Chmod(mode fs.FileMode) error
+	Sync() error
+}
+
+// The real file system, using the os package
+func NewOsFileSystem() FileSystem {
+	return osFileSystem{}
+}
+
+type osFileSystem struct{}
+
+func (osFileSystem) OpenFile(name string, flag int, perm fs.FileMode) (FileSystemFile, error) {
+	var file, err = os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err // no typed nil in the interface
+	}
+	return file, nil
+}
+
+func (osFileSystem) CreateTemp(dir string, pattern string) (FileSystemFile, error) {
+	var file, err = os.CreateTemp(dir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (osFileSystem) Stat(name string) (fs.FileInfo, error)        { return os.Stat(name) }
+func (osFileSystem) ReadDir(name string) ([]fs.DirEntry, error)   { return os.ReadDir(name) }
+func (osFileSystem) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+func (osFileSystem) Remove(name string) error                     { return os.Remove(name) }
+func (osFileSystem) Rename(oldPath string, newPath string) error  { return os.Rename(oldPath, newPath) }
+
+func fsReadFile(fsys FileSystem, name string) ([]byte, error) {
+	var file, err = fsys.OpenFile(name, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(file)
+	return content, NewErrorList(err, file.Close()).Err()
+}
+
+func fsWriteFile(fsys FileSystem, name string, content []byte, flag int, perm fs.FileMode) error {
+	var file, err = fsys.OpenFile(name, flag, perm)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	return NewErrorList(err, file.Close()).Err()
+}
+
+// Makes a file system path (e.g. "/a/b/" or "C:\a\b") an io/fs path (e.g. "a/b"), the root is "."
+func fsPath(name string) string {
+	name = filepath.ToSlash(strings.TrimPrefix(name, filepath.VolumeName(name)))
+	name = path.Clean(StrCat("/", name))[1:]
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// A read-only file system backed by an io/fs.FS, e.g. an embed.FS
+//
+// Paths are looked up relative to the root of the io/fs.FS, so "/a/b.txt", "a/b.txt" and "C:\a\b.txt" are the same file.
+// All modifications fail with fs.ErrPermission
+func NewFileSystemFromFS(fsys fs.FS) FileSystem {
+	return &readOnlyFileSystem{fsys: fsys}
+}
+
+type readOnlyFileSystem struct {
+	fsys fs.FS
+}
+
+const fsWriteFlags = os.O_WRONLY | os.O_RDWR | os.O_APPEND | os.O_CREATE | os.O_TRUNC
+
+func (r *readOnlyFileSystem) OpenFile(name string, flag int, perm fs.FileMode) (FileSystemFile, error) {
+	if flag&fsWriteFlags != 0 {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	var file, err = r.fsys.Open(fsPath(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fsUnwrapPathError(err)}
+	}
+	return &readOnlyFile{File: file, name: name}, nil
+}
+
+func (r *readOnlyFileSystem) CreateTemp(dir string, pattern string) (FileSystemFile, error) {
+	return nil, &fs.PathError{Op: "createtemp", Path: filepath.Join(dir, pattern), Err: fs.ErrPermission}
+}
+
+func (r *readOnlyFileSystem) Stat(name string) (fs.FileInfo, error) {
+	var info, err = fs.Stat(r.fsys, fsPath(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fsUnwrapPathError(err)}
+	}
+	return info, nil
+}
+
+func (r *readOnlyFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries, err = fs.ReadDir(r.fsys, fsPath(name))
+	if err != nil {
+		return entries, &fs.PathError{Op: "readdir", Path: name, Err: fsUnwrapPathError(err)}
+	}
+	return entries, nil
+}
+
+// Existing directories are fine, like with os.MkdirAll
+func (r *readOnlyFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	if info, err := r.Stat(path); err == nil && info.IsDir() {
+		return nil
+	}
+	return &fs.PathError{Op: "mkdir", Path: path, Err: fs.ErrPermission}
+}
+
+func (r *readOnlyFileSystem) Remove(name string) error {
+	return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrPermission}
+}
+
+func (r *readOnlyFileSystem) Rename(oldPath string, newPath string) error {
+	return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrPermission}
+}
+
+// The io/fs errors use the io/fs paths, the full path is more helpful
+func fsUnwrapPathError(err error) error {
+	if pathError, ok := err.(*fs.PathError); ok {
+		return pathError.Err
+	}
+	return err
+}
+
+type readOnlyFile struct {
+	fs.File
+	name string
+}
+
+func (f *readOnlyFile) Name() string { return f.name }
+func (f *readOnlyFile) Sync() error  { return nil }
+
+func (f *readOnlyFile) Write([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrPermission}
+}
+
+func (f *readOnlyFile) Chmod(fs.FileMode) error {
+	return &fs.PathError{Op: "chmod", Path: f.name, Err: fs.ErrPermission}
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestDirWithFileSystem(t *testing.T) {
+	t.Parallel()
+	var dir = sx.NewDir("", "a", "b")
+	if dir.FileSystem() != sx.NewOsFileSystem() || sx.DirTemp().FileSystem() != sx.NewOsFileSystem() {
+		t.FailNow()
+	}
+	var memory = sx.NewMemoryFileSystem()
+	var memoryDir = dir.WithFileSystem(memory)
+	if memoryDir.FileSystem() != memory || memoryDir.String() != dir.String() || memoryDir == dir || dir.FileSystem() != sx.NewOsFileSystem() {
+		t.FailNow()
+	}
+	sx.ThrowIfError(memoryDir.CreateDir("c").Err())
+	var derived = []sx.Dir{
+		memoryDir.CreateDir("c").Value(),
+		memoryDir.Cd("c").Value(),
+		memoryDir.Parent().Value(),
+		memoryDir.Dirs().Value(),
+		memoryDir.File("x.txt").Dir,
+	}
+	for i, d := range derived {
+		if d.FileSystem() != memory {
+			t.Fatal(i)
+		}
+	}
+}
+
+func TestDirJson(t *testing.T) {
+	var config = struct{ Data sx.Dir }{sx.NewDir("", "data").WithFileSystem(sx.NewMemoryFileSystem())}
+	var encoded, err = json.Marshal(config)
+	sx.ThrowIfError(err)
+	if string(encoded) != sx.StrCat(`{"Data":`, strconv.Quote(sx.NewDir("", "data").String()), "}") {
+		t.Fatal(string(encoded))
+	}
+	config.Data = sx.Dir{}
+	sx.ThrowIfError(json.Unmarshal(encoded, &config))
+	if config.Data != sx.NewDir("", "data") {
+		t.FailNow()
+	}
+}
+
+func TestDirOnMemoryFileSystem(t *testing.T) {
+	t.Parallel()
+	var memory = sx.NewMemoryFileSystem()
+	var root = sx.NewDir("", "sx_memory_test").WithFileSystem(memory)
+
+	var dir = root.CreateDir("a/b").Value()
+	if !dir.Exists() || !root.IsDirectory("a") || root.IsFile("a") || !sx.DirRoot().WithFileSystem(memory).Exists() {
+		t.FailNow()
+	}
+	sx.ThrowIfError(dir.WriteAllText("one.txt", "1"))
+	sx.ThrowIfError(dir.WriteAllTextAtomic("two.txt", "22", sx.FileOptions{FileMode: 0600}))
+	sx.ThrowIfError(dir.File("one.txt").Append("1"))
+	var sub = dir.CreateDir("sub").Value()
+	if dir.ReadAllText("one.txt").Value() != "11" || string(dir.ReadAllBytes("two.txt").Value()) != "22" || !dir.IsFile("one.txt") || !sub.Exists() {
+		t.FailNow()
+	}
+	if dir.File("two.txt").Mode().Value() != 0600 || dir.File("one.txt").Size().Value() != 2 {
+		t.FailNow()
+	}
+	if _, err := os.Stat(root.String()); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal("the real disk was used")
+	}
+
+	var names = []string{}
+	for it := dir.NewIterator(); it.Ok(); it.Next() {
+		names = append(names, it.Value())
+	}
+	if !reflect.DeepEqual(names, []string{"one.txt", "sub", "two.txt"}) || dir.Files().Value().Name != "one.txt" || dir.Dirs().Key() != "sub" {
+		t.Fatal(names)
+	}
+	if keys := walkKeys(root.Walk()); !reflect.DeepEqual(keys, []string{"a", "a/b", "a/b/one.txt", "a/b/sub", "a/b/two.txt"}) {
+		t.Fatal(keys)
+	}
+
+	var copied = dir.File("one.txt").CopyTo(sub.File("copy.txt")).Value()
+	var renamed = copied.Rename("renamed.txt").Value()
+	if copied.Exists() || renamed.ReadAllText().Value() != "11" {
+		t.FailNow()
+	}
+	sx.ThrowIfError(renamed.Remove())
+	if renamed.Exists() || dir.ReadAllText("missing.txt").Ok() || dir.Cd("missing").Ok() {
+		t.FailNow()
+	}
+
+	var lw = dir.WriteLines("lines.txt").Value()
+	sx.ThrowIfError(lw.WriteLine("a"))
+	sx.ThrowIfError(lw.WriteLine("b"))
+	sx.ThrowIfError(lw.Close())
+	if lines := readLines(dir.ReadLines("lines.txt").Value()); !reflect.DeepEqual(lines, []string{"a", "b"}) {
+		t.Fatal(lines)
+	}
+}
+
+func TestMemoryFileSystemFaults(t *testing.T) {
+	t.Parallel()
+	var memory = sx.NewMemoryFileSystem()
+	var root = sx.DirRoot().WithFileSystem(memory)
+	var injected = errors.New("disk full")
+
+	memory.FailNth(sx.FileOperationMkdir, 1, injected)
+	if result := root.CreateDir("data"); result.Ok() || !errors.Is(result.Err(), injected) {
+		t.FailNow()
+	}
+	var dir = root.CreateDir("data").Value()
+
+	memory.FailNth(sx.FileOperationWrite, 2, injected)
+	sx.ThrowIfError(dir.WriteAllText("first.txt", "first"))
+	if err := dir.WriteAllText("second.txt", "second"); !errors.Is(err, injected) || dir.ReadAllText("second.txt").Value() != "" {
+		t.FailNow()
+	}
+	sx.ThrowIfError(dir.WriteAllText("second.txt", "second"))
+
+	// the atomic write keeps the old content and removes its temporary file
+	for _, operation := range []sx.FileOperation{sx.FileOperationOpen, sx.FileOperationWrite, sx.FileOperationChmod, sx.FileOperationSync, sx.FileOperationClose, sx.FileOperationRename} {
+		memory.FailNth(operation, 1, injected)
+		if err := dir.WriteAllTextAtomic("first.txt", "new"); !errors.Is(err, injected) {
+			t.Fatal(operation)
+		}
+		if dir.ReadAllText("first.txt").Value() != "first" || dir.Files().Key() != "first.txt" {
+			t.Fatal(operation)
+		}
+	}
+	var writes = memory.Calls(sx.FileOperationWrite)
+	sx.ThrowIfError(dir.WriteAllTextAtomic("first.txt", "new"))
+	if dir.ReadAllText("first.txt").Value() != "new" || memory.Calls(sx.FileOperationWrite) != writes+1 {
+		t.FailNow()
+	}
+
+	memory.FailNth(sx.FileOperationRead, 1, injected)
+	if result := dir.ReadAllText("first.txt"); result.Ok() || !errors.Is(result.Err(), injected) {
+		t.FailNow()
+	}
+	memory.FailNth(sx.FileOperationStat, 1, injected)
+	if dir.Exists() || !dir.Exists() {
+		t.FailNow()
+	}
+
+	var walkErrors = []string{}
+	memory.FailNth(sx.FileOperationReadDir, 1, injected)
+	var it = dir.Walk(sx.WalkOptions{OnError: func(path string, err error) { walkErrors = append(walkErrors, err.Error()) }})
+	if it.Ok() || len(walkErrors) != 1 || !strings.Contains(walkErrors[0], "disk full") {
+		t.Fatal(walkErrors)
+	}
+
+	memory.FailNth(sx.FileOperationRemove, 1, injected)
+	if err := dir.File("first.txt").Remove(); !errors.Is(err, injected) || !dir.IsFile("first.txt") {
+		t.FailNow()
+	}
+}
+
+func TestFileSystemFromFS(t *testing.T) {
+	var mapFs = fstest.MapFS{
+		"config/app.json":  {Data: []byte(`{"debug":true}`)},
+		"config/lines.txt": {Data: []byte("a\r\nb")},
+		"readme.md":        {Data: []byte("# readme")},
+	}
+	t.Parallel()
+	var root = sx.DirRoot().WithFileSystem(sx.NewFileSystemFromFS(mapFs))
+	var dir = root.Cd("config").Value()
+
+	if !dir.Exists() || !dir.IsFile("app.json") || dir.ReadAllText("app.json").Value() != `{"debug":true}` || root.File("readme.md").Size().Value() != 8 {
+		t.FailNow()
+	}
+	if lines := readLines(dir.ReadLines("lines.txt").Value()); !reflect.DeepEqual(lines, []string{"a", "b"}) {
+		t.Fatal(lines)
+	}
+	if keys := walkKeys(root.Walk()); !reflect.DeepEqual(keys, []string{"config", "config/app.json", "config/lines.txt", "readme.md"}) {
+		t.Fatal(keys)
+	}
+	if result := dir.ReadAllText("missing.json"); !errors.Is(result.Err(), fs.ErrNotExist) || !strings.Contains(result.Err().Error(), dir.String()) {
+		t.FailNow()
+	}
+
+	if !dir.CreateDir("").Ok() || dir.CreateDir("new").Ok() {
+		t.FailNow()
+	}
+	var denied = []error{
+		dir.WriteAllText("app.json", "x"),
+		dir.WriteAllTextAtomic("app.json", "x"),
+		dir.File("app.json").Append("x"),
+		dir.File("app.json").Remove(),
+		dir.File("app.json").Rename("x.json").Err(),
+		dir.File("app.json").CopyTo(dir.File("x.json")).Err(),
+		dir.WriteLines("x.txt").Err(),
+	}
+	for i, err := range denied {
+		if !errors.Is(err, fs.ErrPermission) {
+			t.Fatal(i, err)
+		}
+	}
+	if dir.ReadAllText("app.json").Value() != `{"debug":true}` {
+		t.FailNow()
+	}
+}
@@ -1,4 +1,4 @@
 // SPDX-License-Identifier: 0BSD
 module github.com/ZeroBsd/sx
 
-go 1.20
+go 1.23
@@ -1,6 +1,8 @@
 // SPDX-License-Identifier: 0BSD
 package sx
 
+import "iter"
+
 var _ Container = hashMapImpl[string, int]{}
 var _ Map[string, int] = NewHashMap[string, int]()
 var _ Map[string, int] = hashMapImpl[string, int]{}
@@ -24,15 +26,6 @@ func NewHashMapFrom[K comparable, V any](values map[K]V) Map[K, V] {
 	return m
 }
 
-func NewSet[K comparable]() Map[K, struct{}] { return NewSetFrom[K]() }
-func NewSetFrom[K comparable](values ...K) Map[K, struct{}] {
-	var hs = NewHashMap[K, struct{}]()
-	for _, v := range values {
-		hs.Put(v, struct{}{})
-	}
-	return hs
-}
-
 type hashMapImpl[K comparable, V any] struct {
 	Map map[K]V // reference type; needs to be public for reflection access (go maps can be iterated via reflection)
 }
@@ -75,6 +68,10 @@ func (m hashMapImpl[K, V]) NewIterator() Iterator[K, V] {
 	return NewMapIterator(m.Map)
 }
 
+func (m hashMapImpl[K, V]) All() iter.Seq2[K, V] {
+	return Seq2[K, V](m)
+}
+
 type mapIterator[K comparable, V any] struct {
 	Map         map[K]V
 	keyIterator Iterator[int, K]
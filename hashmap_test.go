@@ -129,3 +129,80 @@ func TestSet(t *testing.T) {
 		}
 	}
 }
+
+// Checks the invariants every sx.Map must satisfy, regardless of the implementation
+//
+// 'm' must contain at least one entry, 'missingKey' must not be present
+func checkMapConformance[K comparable, V comparable](t *testing.T, m sx.Map[K, V], missingKey K) {
+	t.Helper()
+	if m.IsEmpty() || m.Length() == 0 || m.IsEmpty() != (m.Length() == 0) {
+		t.FailNow()
+	}
+	if m.Has(missingKey) || m.Get(missingKey).Ok() || m.Drop(missingKey) {
+		t.FailNow()
+	}
+	var visited = map[K]V{}
+	for it := m.NewIterator(); it.Ok(); it.Next() {
+		if _, found := visited[it.Key()]; found {
+			t.Fatal("key visited twice")
+		}
+		visited[it.Key()] = it.Value()
+		if !m.Has(it.Key()) || m.Get(it.Key()).Value() != it.Value() {
+			t.FailNow()
+		}
+	}
+	if len(visited) != m.Length() {
+		t.FailNow()
+	}
+
+	// overwriting an existing key does not change the length
+	for key, value := range visited {
+		if !m.Put(key, value) || m.Length() != len(visited) || m.Get(key).Value() != value {
+			t.FailNow()
+		}
+	}
+}
+
+// Checks the behavior of real maps: every key can be put and dropped
+func checkMapPutDropConformance(t *testing.T, newMap func() sx.Map[int, string]) {
+	t.Helper()
+	var m = newMap()
+	if !m.IsEmpty() || m.Length() != 0 || m.NewIterator().Ok() {
+		t.FailNow()
+	}
+	for i := 0; i < 100; i++ {
+		if !m.Put((i*37)%100, sx.Str(i)) {
+			t.FailNow()
+		}
+	}
+	checkMapConformance(t, m, 100)
+	if m.Length() != 100 {
+		t.FailNow()
+	}
+	for i := 0; i < 100; i += 2 {
+		if !m.Drop(i) || m.Has(i) || m.Drop(i) {
+			t.FailNow()
+		}
+	}
+	checkMapConformance(t, m, 0)
+	if m.Length() != 50 {
+		t.FailNow()
+	}
+	for i := 1; i < 100; i += 2 {
+		m.Drop(i)
+	}
+	if !m.IsEmpty() || m.NewIterator().Ok() {
+		t.FailNow()
+	}
+}
+
+func TestMapConformance(t *testing.T) {
+	checkMapConformance[int, int](t, sx.NewArrayFrom(4, 5, 6), 3)
+	checkMapConformance(t, sx.NewMapFrom(map[string]int{"a": 1, "b": 2}), "c")
+	checkMapConformance(t, sx.NewOrderedMapFrom(sx.NewPair("a", 1), sx.NewPair("b", 2)), "c")
+	checkMapConformance[string, int](t, sx.NewTreeMapFrom(func(a, b string) bool { return a < b }, map[string]int{"a": 1, "b": 2}), "c")
+
+	checkMapPutDropConformance(t, sx.NewHashMap[int, string])
+	checkMapPutDropConformance(t, sx.NewOrderedMap[int, string])
+	checkMapPutDropConformance(t, func() sx.Map[int, string] { return sx.NewTreeMap[int, string](func(a, b int) bool { return a < b }) })
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+var _ Container = hashSetImpl[string]{}
+var _ Set[string, struct{}] = NewSet[string]()
+var _ Map[string, struct{}] = hashSetImpl[string]{}
+var _ HashSet[string] = hashSetImpl[string]{}
+var _ Iterable[string, struct{}] = hashSetImpl[string]{}
+
+func NewSet[K comparable]() HashSet[K] { return NewSetFrom[K]() }
+func NewSetFrom[K comparable](values ...K) HashSet[K] {
+	var hs = &hashSetImpl[K]{hashMapImpl[K, struct{}]{Map: make(map[K]struct{}, len(values))}}
+	hs.Add(values...)
+	return hs
+}
+
+type hashSetImpl[K comparable] struct {
+	hashMapImpl[K, struct{}]
+}
+
+func (s hashSetImpl[K]) Add(keys ...K) {
+	for _, key := range keys {
+		s.Map[key] = struct{}{}
+	}
+}
+
+func (s hashSetImpl[K]) Union(other Set[K, struct{}]) HashSet[K] {
+	var result = NewSet[K]()
+	for it := s.NewIterator(); it.Ok(); it.Next() {
+		result.Add(it.Key())
+	}
+	for it := other.NewIterator(); it.Ok(); it.Next() {
+		result.Add(it.Key())
+	}
+	return result
+}
+
+func (s hashSetImpl[K]) Intersect(other Set[K, struct{}]) HashSet[K] {
+	return s.filter(func(key K) bool { return other.Has(key) })
+}
+
+func (s hashSetImpl[K]) Difference(other Set[K, struct{}]) HashSet[K] {
+	return s.filter(func(key K) bool { return !other.Has(key) })
+}
+
+func (s hashSetImpl[K]) SymmetricDifference(other Set[K, struct{}]) HashSet[K] {
+	var result = s.Difference(other)
+	for it := other.NewIterator(); it.Ok(); it.Next() {
+		if !s.Has(it.Key()) {
+			result.Add(it.Key())
+		}
+	}
+	return result
+}
+
+func (s hashSetImpl[K]) IsSubsetOf(other Set[K, struct{}]) bool {
+	if s.Length() > other.Length() {
+		return false
+	}
+	for key := range s.Map {
+		if !other.Has(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s hashSetImpl[K]) IsSupersetOf(other Set[K, struct{}]) bool {
+	if s.Length() < other.Length() {
+		return false
+	}
+	for it := other.NewIterator(); it.Ok(); it.Next() {
+		if !s.Has(it.Key()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s hashSetImpl[K]) Equals(other Set[K, struct{}]) bool {
+	return s.Length() == other.Length() && s.IsSubsetOf(other)
+}
+
+func (s hashSetImpl[K]) filter(keep func(key K) bool) HashSet[K] {
+	var result = NewSet[K]()
+	for key := range s.Map {
+		if keep(key) {
+			result.Add(key)
+		}
+	}
+	return result
+}
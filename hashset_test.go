@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestSetAdd(t *testing.T) {
+	var set = sx.NewSet[int]()
+	set.Add(1, 2, 2, 3)
+	if set.Length() != 3 || !set.Has(1) || !set.Has(2) || !set.Has(3) || set.Has(4) {
+		t.FailNow()
+	}
+	set.Add()
+	if set.Length() != 3 {
+		t.FailNow()
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	var a = sx.NewSetFrom(1, 2, 3, 4)
+	var b = sx.NewSetFrom(3, 4, 5)
+
+	if union := a.Union(b); !union.Equals(sx.NewSetFrom(1, 2, 3, 4, 5)) {
+		t.FailNow()
+	}
+	if intersection := a.Intersect(b); !intersection.Equals(sx.NewSetFrom(3, 4)) {
+		t.FailNow()
+	}
+	if difference := a.Difference(b); !difference.Equals(sx.NewSetFrom(1, 2)) {
+		t.FailNow()
+	}
+	if difference := b.Difference(a); !difference.Equals(sx.NewSetFrom(5)) {
+		t.FailNow()
+	}
+	if symmetric := a.SymmetricDifference(b); !symmetric.Equals(sx.NewSetFrom(1, 2, 5)) {
+		t.FailNow()
+	}
+	if !a.Intersect(sx.NewSet[int]()).IsEmpty() || !a.Union(sx.NewSet[int]()).Equals(a) {
+		t.FailNow()
+	}
+
+	// operations never modify their inputs
+	if a.Length() != 4 || b.Length() != 3 {
+		t.FailNow()
+	}
+}
+
+func TestSetRelations(t *testing.T) {
+	var small = sx.NewSetFrom("a", "b")
+	var large = sx.NewSetFrom("a", "b", "c")
+	var other = sx.NewSetFrom("a", "x", "y")
+	if !small.IsSubsetOf(large) || large.IsSubsetOf(small) || !small.IsSubsetOf(small) || other.IsSubsetOf(large) {
+		t.FailNow()
+	}
+	if !large.IsSupersetOf(small) || small.IsSupersetOf(large) || !large.IsSupersetOf(large) || large.IsSupersetOf(other) {
+		t.FailNow()
+	}
+	if !small.Equals(sx.NewSetFrom("b", "a")) || small.Equals(large) || large.Equals(other) {
+		t.FailNow()
+	}
+	if !sx.NewSet[string]().IsSubsetOf(small) || !small.IsSupersetOf(sx.NewSet[string]()) {
+		t.FailNow()
+	}
+}
+
+func TestSetWithMapArguments(t *testing.T) {
+	// every sx.Set with empty struct values can be used as argument, e.g. a sorted map
+	var sorted = sx.NewTreeMap[int, struct{}](intLess)
+	sorted.Put(2, struct{}{})
+	sorted.Put(3, struct{}{})
+	var set = sx.NewSetFrom(1, 2)
+	if !set.Union(sorted).Equals(sx.NewSetFrom(1, 2, 3)) || !set.Intersect(sorted).Equals(sx.NewSetFrom(2)) {
+		t.FailNow()
+	}
+	if !sx.NewSetFrom(2).IsSubsetOf(sorted) || !set.Union(sorted).IsSupersetOf(sorted) {
+		t.FailNow()
+	}
+}
+
+func TestSetConformance(t *testing.T) {
+	checkMapConformance[string, struct{}](t, sx.NewSetFrom("a", "b"), "c")
+}
@@ -38,6 +38,13 @@ type Stack[V any] interface {
 	Peek() (value Result[V])     // Returns last value (if present)
 }
 
+type Queue[V any] interface {
+	Container
+	PushBack(values ...V) // Inserts all values at the end
+	PopFront() Result[V]  // Removes and returns first value (if present)
+	PeekFront() Result[V] // Returns first value (if present)
+}
+
 type Array[V any] interface {
 	Iterable[int, V]
 	Stack[V]
@@ -45,3 +52,54 @@ type Array[V any] interface {
 	Compact()                             // Copies Array and removes excessive memory
 	SubSlice(fromIndexToIndex ...int) []V // Returns a go slice
 }
+
+type Deque[V any] interface {
+	Array[V]
+	Queue[V]
+	PushFront(values ...V) // Inserts all values at the front, they keep their order
+	PopBack() Result[V]    // Removes and returns last value (if present), same as Pop
+	PeekBack() Result[V]   // Returns last value (if present), same as Peek
+}
+
+type SortedMap[K comparable, V any] interface {
+	Map[K, V]
+	Min() Optional[Pair[K, V]]         // Returns the entry with the smallest key (if present)
+	Max() Optional[Pair[K, V]]         // Returns the entry with the largest key (if present)
+	Floor(K) Optional[Pair[K, V]]      // Returns the entry with the largest key less than or equal to the given key (if present)
+	Ceiling(K) Optional[Pair[K, V]]    // Returns the entry with the smallest key greater than or equal to the given key (if present)
+	Range(from K, to K) Iterator[K, V] // Iterates all entries with keys in [from, to) in sorted order
+}
+
+type HashSet[K comparable] interface {
+	Map[K, struct{}]
+	Add(keys ...K)                                         // Inserts all keys
+	Union(other Set[K, struct{}]) HashSet[K]               // Returns a new set with all keys that are in either set
+	Intersect(other Set[K, struct{}]) HashSet[K]           // Returns a new set with all keys that are in both sets
+	Difference(other Set[K, struct{}]) HashSet[K]          // Returns a new set with all keys that are not in the other set
+	SymmetricDifference(other Set[K, struct{}]) HashSet[K] // Returns a new set with all keys that are in exactly one of the sets
+	IsSubsetOf(other Set[K, struct{}]) bool                // Checks if all keys are in the other set
+	IsSupersetOf(other Set[K, struct{}]) bool              // Checks if all keys of the other set are in this set
+	Equals(other Set[K, struct{}]) bool                    // Checks if both sets contain exactly the same keys
+}
+
+type ConcurrentMap[K comparable, V any] interface {
+	Map[K, V]
+	GetOrPut(key K, value V) (actual V, loaded bool)                       // Returns the existing value, or stores and returns the given value
+	Compute(key K, remapper func(old Optional[V]) Optional[V]) Optional[V] // Replaces the value atomically, an empty Optional drops the key
+	CompareAndSwap(key K, old V, new V) bool                               // Replaces the value iff the current value equals 'old'
+}
+
+type PriorityQueue[V any] interface {
+	Stack[V]
+	PushHandle(value V) PriorityQueueHandle[V]          // Inserts a value and returns a handle to change or remove it later
+	Update(handle PriorityQueueHandle[V], value V) bool // Replaces the value of a queued item and restores the heap order
+	Fix(handle PriorityQueueHandle[V]) bool             // Restores the heap order after the queued value was modified in place
+	Remove(handle PriorityQueueHandle[V]) Result[V]     // Removes a queued item and returns its value
+	Drain() Iterator[int, V]                            // Pops all values in heap order, lazily
+}
+
+type Cache[K comparable, V any] interface {
+	Map[K, V]
+	OnEvict(callback func(key K, value V)) // Sets a callback for entries that are evicted or expire (not for dropped entries)
+	Stats() CacheStats                     // Returns the hit/miss/eviction counters
+}
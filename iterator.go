@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+// Lazy iterator adapters
+//
+// Adapters wrap any sx.Iterator and return another sx.Iterator. Nothing is evaluated
+// until the resulting iterator is used, so pipelines do not create intermediate containers.
+// Adapters take ownership of their source iterators, don't advance them from the outside.
+
+var _ Iterator[int, int] = &filterIterator[int, int]{}
+var _ Iterator[int, string] = &mapIterIterator[int, int, string]{}
+var _ Iterator[int, int] = &takeIterator[int, int]{}
+var _ Iterator[int, int] = &skipIterator[int, int]{}
+var _ Iterator[int, int] = &takeWhileIterator[int, int]{}
+var _ Iterator[int, int] = &chainIterator[int, int]{}
+var _ Iterator[int, Pair[int, string]] = &zipIterator[int, int, int, string]{}
+var _ Iterator[int, Pair[string, int]] = &enumerateIterator[string, int]{}
+var _ Iterator[int, int] = &flattenIterator[string, Array[int], int, int]{}
+
+// Only yields the items where the condition is true
+func Filter[K any, V any](source Iterator[K, V], condition func(key K, value V) bool) Iterator[K, V] {
+	return &filterIterator[K, V]{source: source, condition: condition}
+}
+
+// Converts every value, the keys stay the same
+//
+// The mapper is called at most once per item
+func MapIter[K any, V any, TO any](source Iterator[K, V], mapper func(key K, value V) TO) Iterator[K, TO] {
+	return &mapIterIterator[K, V, TO]{source: source, mapper: mapper}
+}
+
+// Yields at most the first 'count' items
+func Take[K any, V any](source Iterator[K, V], count int) Iterator[K, V] {
+	return &takeIterator[K, V]{source: source, remaining: count}
+}
+
+// Skips the first 'count' items
+func Skip[K any, V any](source Iterator[K, V], count int) Iterator[K, V] {
+	return &skipIterator[K, V]{source: source, remaining: count}
+}
+
+// Yields items as long as the condition is true, stops at the first item where it is false
+func TakeWhile[K any, V any](source Iterator[K, V], condition func(key K, value V) bool) Iterator[K, V] {
+	return &takeWhileIterator[K, V]{source: source, condition: condition}
+}
+
+// Yields all items of the first iterator, then all items of the second iterator, and so on
+func Chain[K any, V any](sources ...Iterator[K, V]) Iterator[K, V] {
+	return &chainIterator[K, V]{sources: sources}
+}
+
+// Combines the values of two iterators pairwise, stops when one of them ends
+//
+// The keys are the positions, starting at 0
+func Zip[K1 any, V1 any, K2 any, V2 any](first Iterator[K1, V1], second Iterator[K2, V2]) Iterator[int, Pair[V1, V2]] {
+	return &zipIterator[K1, V1, K2, V2]{first: first, second: second}
+}
+
+// Yields the key/value pairs of the source, keyed by their position (starting at 0)
+func Enumerate[K any, V any](source Iterator[K, V]) Iterator[int, Pair[K, V]] {
+	return &enumerateIterator[K, V]{source: source}
+}
+
+// Yields all items of all nested containers (e.g. an Array of Arrays) one after another
+func Flatten[K any, I Iterable[K2, V], K2 any, V any](source Iterator[K, I]) Iterator[K2, V] {
+	return &flattenIterator[K, I, K2, V]{source: source}
+}
+
+// Terminal operations
+//
+// These functions consume the iterator.
+
+// Collects all values into a new sx.Array, the keys are dropped
+func CollectArray[K any, V any](source Iterator[K, V]) Array[V] {
+	var arr = NewArray[V]()
+	for ; source.Ok(); source.Next() {
+		arr.Push(source.Value())
+	}
+	return arr
+}
+
+// Collects all key/value pairs into a new sx.Map, later keys overwrite earlier ones
+func CollectMap[K comparable, V any](source Iterator[K, V]) Map[K, V] {
+	var m = NewMap[K, V]()
+	for ; source.Ok(); source.Next() {
+		m.Put(source.Key(), source.Value())
+	}
+	return m
+}
+
+// Combines all items into a single value, starting with 'initial'
+func Reduce[K any, V any, TO any](source Iterator[K, V], initial TO, reducer func(accumulator TO, key K, value V) TO) TO {
+	var accumulator = initial
+	for ; source.Ok(); source.Next() {
+		accumulator = reducer(accumulator, source.Key(), source.Value())
+	}
+	return accumulator
+}
+
+// Counts the items
+func Count[K any, V any](source Iterator[K, V]) int {
+	var count = 0
+	for ; source.Ok(); source.Next() {
+		count++
+	}
+	return count
+}
+
+// Checks if the condition is true for at least one item, stops at the first match
+func Any[K any, V any](source Iterator[K, V], condition func(key K, value V) bool) bool {
+	for ; source.Ok(); source.Next() {
+		if condition(source.Key(), source.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if the condition is true for all items, stops at the first mismatch
+//
+// Returns true for empty iterators
+func All[K any, V any](source Iterator[K, V], condition func(key K, value V) bool) bool {
+	return !Any(source, func(key K, value V) bool { return !condition(key, value) })
+}
+
+type filterIterator[K any, V any] struct {
+	source    Iterator[K, V]
+	condition func(key K, value V) bool
+	matched   bool // the source is positioned on an item that satisfies the condition
+}
+
+func (it *filterIterator[K, V]) Ok() bool {
+	for ; !it.matched && it.source.Ok(); it.source.Next() {
+		if it.condition(it.source.Key(), it.source.Value()) {
+			it.matched = true
+			return true
+		}
+	}
+	return it.matched
+}
+
+func (it *filterIterator[K, V]) Key() K   { it.Ok(); return it.source.Key() }
+func (it *filterIterator[K, V]) Value() V { it.Ok(); return it.source.Value() }
+func (it *filterIterator[K, V]) Next() {
+	if it.Ok() {
+		it.source.Next()
+		it.matched = false
+	}
+}
+
+type mapIterIterator[K any, V any, TO any] struct {
+	source Iterator[K, V]
+	mapper func(key K, value V) TO
+	value  Optional[TO] // cached result of the mapper for the current item
+}
+
+func (it *mapIterIterator[K, V, TO]) Ok() bool { return it.source.Ok() }
+func (it *mapIterIterator[K, V, TO]) Key() K   { return it.source.Key() }
+func (it *mapIterIterator[K, V, TO]) Value() TO {
+	if it.value.IsEmpty() {
+		it.value = NewOptionalFrom(it.mapper(it.source.Key(), it.source.Value()))
+	}
+	return it.value.Value()
+}
+func (it *mapIterIterator[K, V, TO]) Next() {
+	it.source.Next()
+	it.value = NewOptional[TO]()
+}
+
+type takeIterator[K any, V any] struct {
+	source    Iterator[K, V]
+	remaining int
+}
+
+func (it *takeIterator[K, V]) Ok() bool { return it.remaining > 0 && it.source.Ok() }
+func (it *takeIterator[K, V]) Key() K   { return it.source.Key() }
+func (it *takeIterator[K, V]) Value() V { return it.source.Value() }
+func (it *takeIterator[K, V]) Next() {
+	if it.Ok() {
+		it.source.Next()
+		it.remaining--
+	}
+}
+
+type skipIterator[K any, V any] struct {
+	source    Iterator[K, V]
+	remaining int
+}
+
+func (it *skipIterator[K, V]) Ok() bool {
+	for ; it.remaining > 0 && it.source.Ok(); it.remaining-- {
+		it.source.Next()
+	}
+	return it.source.Ok()
+}
+
+func (it *skipIterator[K, V]) Key() K   { it.Ok(); return it.source.Key() }
+func (it *skipIterator[K, V]) Value() V { it.Ok(); return it.source.Value() }
+func (it *skipIterator[K, V]) Next() {
+	if it.Ok() {
+		it.source.Next()
+	}
+}
+
+type takeWhileIterator[K any, V any] struct {
+	source    Iterator[K, V]
+	condition func(key K, value V) bool
+	checked   bool // the condition was already checked for the current item
+	stopped   bool
+}
+
+func (it *takeWhileIterator[K, V]) Ok() bool {
+	if !it.checked && !it.stopped {
+		it.stopped = !it.source.Ok() || !it.condition(it.source.Key(), it.source.Value())
+		it.checked = true
+	}
+	return !it.stopped
+}
+
+func (it *takeWhileIterator[K, V]) Key() K   { return it.source.Key() }
+func (it *takeWhileIterator[K, V]) Value() V { return it.source.Value() }
+func (it *takeWhileIterator[K, V]) Next() {
+	if it.Ok() {
+		it.source.Next()
+		it.checked = false
+	}
+}
+
+type chainIterator[K any, V any] struct {
+	sources []Iterator[K, V]
+}
+
+func (it *chainIterator[K, V]) Ok() bool {
+	for len(it.sources) > 0 && !it.sources[0].Ok() {
+		it.sources = it.sources[1:]
+	}
+	return len(it.sources) > 0
+}
+
+func (it *chainIterator[K, V]) Key() K   { it.Ok(); return it.sources[0].Key() }
+func (it *chainIterator[K, V]) Value() V { it.Ok(); return it.sources[0].Value() }
+func (it *chainIterator[K, V]) Next() {
+	if it.Ok() {
+		it.sources[0].Next()
+	}
+}
+
+type zipIterator[K1 any, V1 any, K2 any, V2 any] struct {
+	first  Iterator[K1, V1]
+	second Iterator[K2, V2]
+	index  int
+}
+
+func (it *zipIterator[K1, V1, K2, V2]) Ok() bool { return it.first.Ok() && it.second.Ok() }
+func (it *zipIterator[K1, V1, K2, V2]) Key() int { return it.index }
+func (it *zipIterator[K1, V1, K2, V2]) Value() Pair[V1, V2] {
+	return NewPair(it.first.Value(), it.second.Value())
+}
+func (it *zipIterator[K1, V1, K2, V2]) Next() {
+	if it.Ok() {
+		it.first.Next()
+		it.second.Next()
+		it.index++
+	}
+}
+
+type enumerateIterator[K any, V any] struct {
+	source Iterator[K, V]
+	index  int
+}
+
+func (it *enumerateIterator[K, V]) Ok() bool { return it.source.Ok() }
+func (it *enumerateIterator[K, V]) Key() int { return it.index }
+func (it *enumerateIterator[K, V]) Value() Pair[K, V] {
+	return NewPair(it.source.Key(), it.source.Value())
+}
+func (it *enumerateIterator[K, V]) Next() {
+	if it.Ok() {
+		it.source.Next()
+		it.index++
+	}
+}
+
+type flattenIterator[K any, I Iterable[K2, V], K2 any, V any] struct {
+	source Iterator[K, I]
+	inner  Iterator[K2, V]
+}
+
+func (it *flattenIterator[K, I, K2, V]) Ok() bool {
+	for it.inner == nil || !it.inner.Ok() {
+		if !it.source.Ok() {
+			return false
+		}
+		it.inner = it.source.Value().NewIterator()
+		it.source.Next()
+	}
+	return true
+}
+
+func (it *flattenIterator[K, I, K2, V]) Key() K2  { it.Ok(); return it.inner.Key() }
+func (it *flattenIterator[K, I, K2, V]) Value() V { it.Ok(); return it.inner.Value() }
+func (it *flattenIterator[K, I, K2, V]) Next() {
+	if it.Ok() {
+		it.inner.Next()
+	}
+}
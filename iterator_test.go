@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func isEven(_ int, v int) bool { return v%2 == 0 }
+
+func TestFilter(t *testing.T) {
+	var arr = sx.NewArrayFrom(1, 2, 3, 4, 5, 6)
+	var it = sx.Filter(arr.NewIterator(), isEven)
+	if !it.Ok() || !it.Ok() || it.Key() != 1 || it.Value() != 2 {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(sx.CollectArray(it).SubSlice(), []int{2, 4, 6}) {
+		t.FailNow()
+	}
+	if it.Ok() {
+		t.FailNow()
+	}
+	it.Next()
+	if sx.Filter(sx.NewArray[int]().NewIterator(), isEven).Ok() {
+		t.FailNow()
+	}
+}
+
+func TestFilterIsLazy(t *testing.T) {
+	var calls = 0
+	var it = sx.Filter(sx.NewArrayFrom(1, 2, 3, 4).NewIterator(), func(_ int, v int) bool { calls++; return v > 1 })
+	if calls != 0 {
+		t.FailNow()
+	}
+	it.Ok()
+	it.Key()
+	it.Value()
+	if calls != 2 {
+		t.FailNow()
+	}
+	var taken = sx.CollectArray(sx.Take(it, 1))
+	if taken.Length() != 1 || taken.Get(0).Value() != 2 || calls != 2 {
+		t.FailNow()
+	}
+}
+
+func TestMapIter(t *testing.T) {
+	var calls = 0
+	var it = sx.MapIter(sx.NewArrayFrom("a", "bb").NewIterator(), func(k int, v string) int { calls++; return len(v) * 10 })
+	if !it.Ok() || it.Key() != 0 || it.Value() != 10 || it.Value() != 10 || calls != 1 {
+		t.FailNow()
+	}
+	it.Next()
+	if !it.Ok() || it.Key() != 1 || it.Value() != 20 || calls != 2 {
+		t.FailNow()
+	}
+	it.Next()
+	if it.Ok() {
+		t.FailNow()
+	}
+}
+
+func TestTakeAndSkip(t *testing.T) {
+	var arr = sx.NewArrayFrom(1, 2, 3, 4, 5)
+	if !reflect.DeepEqual(sx.CollectArray(sx.Take(arr.NewIterator(), 2)).SubSlice(), []int{1, 2}) {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(sx.CollectArray(sx.Take(arr.NewIterator(), 10)).SubSlice(), []int{1, 2, 3, 4, 5}) {
+		t.FailNow()
+	}
+	if taken := sx.Take(arr.NewIterator(), 1); taken.Key() != 0 || taken.Value() != 1 {
+		t.FailNow()
+	}
+	if sx.Take(arr.NewIterator(), 0).Ok() {
+		t.FailNow()
+	}
+	var skipped = sx.Skip(arr.NewIterator(), 3)
+	if skipped.Key() != 3 || skipped.Value() != 4 {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(sx.CollectArray(skipped).SubSlice(), []int{4, 5}) {
+		t.FailNow()
+	}
+	skipped.Next()
+	if sx.Skip(arr.NewIterator(), 10).Ok() {
+		t.FailNow()
+	}
+	var page = sx.Take(sx.Skip(arr.NewIterator(), 1), 3)
+	if !reflect.DeepEqual(sx.CollectArray(page).SubSlice(), []int{2, 3, 4}) {
+		t.FailNow()
+	}
+	page.Next()
+}
+
+func TestTakeWhile(t *testing.T) {
+	var arr = sx.NewArrayFrom(2, 4, 5, 6)
+	var it = sx.TakeWhile(arr.NewIterator(), isEven)
+	if !it.Ok() || it.Key() != 0 || it.Value() != 2 {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(sx.CollectArray(it).SubSlice(), []int{2, 4}) {
+		t.FailNow()
+	}
+	it.Next()
+	if it.Ok() || sx.TakeWhile(sx.NewArray[int]().NewIterator(), isEven).Ok() {
+		t.FailNow()
+	}
+}
+
+func TestChain(t *testing.T) {
+	var it = sx.Chain(sx.NewArrayFrom(1, 2).NewIterator(), sx.NewArray[int]().NewIterator(), sx.NewArrayFrom(3).NewIterator())
+	if it.Key() != 0 || it.Value() != 1 {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(sx.CollectArray(it).SubSlice(), []int{1, 2, 3}) {
+		t.FailNow()
+	}
+	it.Next()
+	if sx.Chain[int, int]().Ok() {
+		t.FailNow()
+	}
+}
+
+func TestZipAndEnumerate(t *testing.T) {
+	var zipped = sx.Zip(sx.NewArrayFrom("a", "b", "c").NewIterator(), sx.NewArrayFrom(1, 2).NewIterator())
+	var pairs = sx.CollectMap(zipped)
+	if pairs.Length() != 2 || pairs.Get(0).Value() != sx.NewPair("a", 1) || pairs.Get(1).Value() != sx.NewPair("b", 2) {
+		t.FailNow()
+	}
+	zipped.Next()
+
+	var m = sx.NewOrderedMapFrom(sx.NewPair("x", 10), sx.NewPair("y", 20))
+	var enumerated = sx.Enumerate(m.NewIterator())
+	if !enumerated.Ok() || enumerated.Key() != 0 || enumerated.Value() != sx.NewPair("x", 10) {
+		t.FailNow()
+	}
+	enumerated.Next()
+	if enumerated.Key() != 1 || enumerated.Value() != sx.NewPair("y", 20) {
+		t.FailNow()
+	}
+	enumerated.Next()
+	enumerated.Next()
+	if enumerated.Ok() {
+		t.FailNow()
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	var nested = sx.NewArrayFrom(sx.NewArrayFrom(1, 2), sx.NewArray[int](), sx.NewArrayFrom(3))
+	var it = sx.Flatten(nested.NewIterator())
+	if it.Key() != 0 || it.Value() != 1 {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(sx.CollectArray(it).SubSlice(), []int{1, 2, 3}) {
+		t.FailNow()
+	}
+	it.Next()
+	if sx.Flatten(sx.NewArray[sx.Array[int]]().NewIterator()).Ok() {
+		t.FailNow()
+	}
+}
+
+func TestTerminalOperations(t *testing.T) {
+	var arr = sx.NewArrayFrom(1, 2, 3, 4)
+	if sx.Count(arr.NewIterator()) != 4 || sx.Count(sx.Filter(arr.NewIterator(), isEven)) != 2 {
+		t.FailNow()
+	}
+	var sum = sx.Reduce(arr.NewIterator(), 0, func(acc int, _ int, v int) int { return acc + v })
+	if sum != 10 {
+		t.FailNow()
+	}
+	var text = sx.Reduce(arr.NewIterator(), "", func(acc string, k int, v int) string { return sx.Str(acc, k, "=", v, ";") })
+	if text != "0=1;1=2;2=3;3=4;" {
+		t.FailNow()
+	}
+	if !sx.Any(arr.NewIterator(), isEven) || sx.Any(sx.NewArray[int]().NewIterator(), isEven) {
+		t.FailNow()
+	}
+	if sx.All(arr.NewIterator(), isEven) || !sx.All(sx.Filter(arr.NewIterator(), isEven), isEven) || !sx.All(sx.NewArray[int]().NewIterator(), isEven) {
+		t.FailNow()
+	}
+	var m = sx.CollectMap(sx.MapIter(arr.NewIterator(), func(k int, v int) string { return sx.Str(v * v) }))
+	if m.Length() != 4 || m.Get(3).Value() != "16" {
+		t.FailNow()
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	var words = sx.NewArrayFrom("alpha", "beta", "gamma", "delta", "epsilon")
+	var lengths = sx.MapIter(words.NewIterator(), func(_ int, w string) int { return len(w) })
+	var long = sx.Filter(lengths, func(_ int, l int) bool { return l > 4 })
+	var result = sx.CollectArray(sx.Take(sx.Skip(long, 1), 2))
+	if !reflect.DeepEqual(result.SubSlice(), []int{5, 5}) {
+		t.FailNow()
+	}
+}
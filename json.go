@@ -1,7 +1,12 @@
 // SPDX-License-Identifier: 0BSD
 package sx
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+)
 
 // Tries to convert object to a compact/dense json string
 func ToJson(object any) Result[string] {
@@ -52,3 +57,171 @@ func (m hashMapImpl[K, V]) MarshalJSON() ([]byte, error) {
 func (m hashMapImpl[K, V]) UnmarshalJSON(bytes []byte) error {
 	return json.Unmarshal(bytes, &m.Map)
 }
+
+// Writes the json object in insertion order
+func (m *orderedMapImpl[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalJsonObject(m.NewIterator())
+}
+
+// Reads the json object and keeps the order of the keys
+func (m *orderedMapImpl[K, V]) UnmarshalJSON(jsonBytes []byte) error {
+	return unmarshalJsonObject(jsonBytes, m.Put)
+}
+
+// Writes a json object in the order of the iterator
+//
+// Keys and values are encoded exactly like a go map would be encoded
+func marshalJsonObject[K comparable, V any](it Iterator[K, V]) ([]byte, error) {
+	var buffer = []byte{'{'}
+	for ; it.Ok(); it.Next() {
+		var bytes, err = json.Marshal(map[K]V{it.Key(): it.Value()})
+		if err != nil {
+			return nil, err
+		}
+		if len(buffer) > 1 {
+			buffer = append(buffer, ',')
+		}
+		buffer = append(buffer, bytes[1:len(bytes)-1]...) // strip the braces of the single entry object
+	}
+	return append(buffer, '}'), nil
+}
+
+// Reads a json object and calls 'put' for every key/value pair in the order of the json text
+func unmarshalJsonObject[K comparable, V any](jsonBytes []byte, put func(K, V) bool) error {
+	var decoder = json.NewDecoder(bytes.NewReader(jsonBytes))
+	var token, err = decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("Fatal error: json value is not an object")
+	}
+	for decoder.More() {
+		if token, err = decoder.Token(); err != nil {
+			return err
+		}
+		var key, keyErr = jsonObjectKey[K](token.(string))
+		if keyErr != nil {
+			return keyErr
+		}
+		var value V
+		if err = decoder.Decode(&value); err != nil {
+			return err
+		}
+		put(key, value)
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// Decodes a json object key exactly like a go map key would be decoded
+func jsonObjectKey[K comparable](key string) (k K, err error) {
+	var quotedKey []byte
+	if quotedKey, err = json.Marshal(key); err != nil {
+		return k, err
+	}
+	var single map[K]struct{}
+	if err = json.Unmarshal([]byte(StrCat("{", string(quotedKey), ":{}}")), &single); err != nil {
+		return k, err
+	}
+	for k = range single {
+		return k, nil
+	}
+	return k, errors.New(StrCat("Fatal error: cannot decode json key '", key, "'"))
+}
+
+// Writes the json object in sorted key order
+func (m *treeMapImpl[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalJsonObject(m.NewIterator())
+}
+
+func (m *treeMapImpl[K, V]) UnmarshalJSON(jsonBytes []byte) error {
+	return unmarshalJsonObject(jsonBytes, m.Put)
+}
+
+// Writes the set as json array
+//
+// The keys are sorted by their json representation, so the output is stable
+func (s hashSetImpl[K]) MarshalJSON() ([]byte, error) {
+	var encodedKeys = make([]string, 0, s.Length())
+	for key := range s.Map {
+		var bytes, err = json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedKeys = append(encodedKeys, string(bytes))
+	}
+	sort.Strings(encodedKeys)
+	return []byte(StrCat("[", StrJoin(",", encodedKeys...), "]")), nil
+}
+
+// Reads a json array, all items are added to the set
+func (s *hashSetImpl[K]) UnmarshalJSON(jsonBytes []byte) error {
+	var keys []K
+	var err = json.Unmarshal(jsonBytes, &keys)
+	if err != nil {
+		return err
+	}
+	s.Add(keys...)
+	return nil
+}
+
+func (m *concurrentMapImpl[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.snapshot())
+}
+
+func (m *concurrentMapImpl[K, V]) UnmarshalJSON(jsonBytes []byte) error {
+	var values map[K]V
+	var err = json.Unmarshal(jsonBytes, &values)
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for key, value := range values {
+		m.values[key] = value
+	}
+	return nil
+}
+
+func (d *dequeImpl[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.SubSlice())
+}
+
+// Replaces all values with the values of the json array
+func (d *dequeImpl[V]) UnmarshalJSON(jsonBytes []byte) error {
+	var values []V
+	var err = json.Unmarshal(jsonBytes, &values)
+	if err != nil {
+		return err
+	}
+	*d = dequeImpl[V]{}
+	d.PushBack(values...)
+	return nil
+}
+
+// Empty optionals are encoded as json null
+func (opt Optional[T]) MarshalJSON() ([]byte, error) {
+	if opt.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(opt.data)
+}
+
+// Json null results in an empty optional, missing struct fields leave the optional unchanged (i.e. empty)
+func (opt *Optional[T]) UnmarshalJSON(jsonBytes []byte) error {
+	if bytes.Equal(bytes.TrimSpace(jsonBytes), []byte("null")) {
+		*opt = NewOptional[T]()
+		return nil
+	}
+	var value T
+	var err = json.Unmarshal(jsonBytes, &value)
+	if err != nil {
+		return err
+	}
+	*opt = NewOptionalFrom(value)
+	return nil
+}
@@ -2,6 +2,7 @@
 package sx_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -112,3 +113,139 @@ func TestJsonWithMap(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestJsonWithOrderedMap(t *testing.T) {
+	var m = sx.NewOrderedMapFrom(sx.NewPair("b", 2), sx.NewPair("a", 1), sx.NewPair("c", 3))
+	if json := sx.ToJson(m).ValueOrInit(); json != `{"b":2,"a":1,"c":3}` {
+		t.FailNow()
+	}
+	if json := sx.ToJson(sx.NewOrderedMap[string, int]()).ValueOrInit(); json != `{}` {
+		t.FailNow()
+	}
+
+	sx.FromJsonInterface[sx.Map[string, int]](`{"z":26,"a":0,"d":4}`, &m)
+	if json := sx.ToJson(m).ValueOrInit(); json != `{"b":2,"a":0,"c":3,"z":26,"d":4}` {
+		t.FailNow()
+	}
+
+	var intKeys = sx.NewOrderedMap[int, string]()
+	sx.FromJsonInterface(`{"3":"c","1":"a","2":"b"}`, &intKeys)
+	if json := sx.ToJson(intKeys).ValueOrInit(); json != `{"3":"c","1":"a","2":"b"}` {
+		t.FailNow()
+	}
+
+	// negative tests: no object, invalid key, invalid value, broken json
+	if sx.FromJsonInterface(`[1,2]`, &m).Ok() {
+		t.FailNow()
+	}
+	if sx.FromJsonInterface(`{"x":"c"}`, &intKeys).Ok() {
+		t.FailNow()
+	}
+	if sx.FromJsonInterface(`{"1":2}`, &intKeys).Ok() {
+		t.FailNow()
+	}
+	if sx.FromJsonInterface(`{"1":"a"`, &intKeys).Ok() {
+		t.FailNow()
+	}
+	if sx.ToJson(sx.NewOrderedMapFrom(sx.NewPair("f", func() {}))).Ok() {
+		t.FailNow()
+	}
+}
+
+func TestJsonWithTreeMap(t *testing.T) {
+	var m = sx.NewTreeMapFrom(func(a, b string) bool { return a > b }, map[string]int{"a": 1, "c": 3, "b": 2})
+	if json := sx.ToJson(m).ValueOrInit(); json != `{"c":3,"b":2,"a":1}` {
+		t.FailNow()
+	}
+	sx.FromJsonInterface(`{"d":4}`, &m)
+	if json := sx.ToJson(m).ValueOrInit(); json != `{"d":4,"c":3,"b":2,"a":1}` {
+		t.FailNow()
+	}
+}
+
+func TestJsonWithSet(t *testing.T) {
+	var set = sx.NewSetFrom("c", "a", "b")
+	if json := sx.ToJson(set).ValueOrInit(); json != `["a","b","c"]` {
+		t.FailNow()
+	}
+	if json := sx.ToJson(sx.NewSetFrom(10, 9, 1)).ValueOrInit(); json != `[1,10,9]` {
+		t.FailNow()
+	}
+	if json := sx.ToJson(sx.NewSet[int]()).ValueOrInit(); json != `[]` {
+		t.FailNow()
+	}
+
+	sx.FromJsonInterface(`["d","a"]`, &set)
+	if !set.Equals(sx.NewSetFrom("a", "b", "c", "d")) {
+		t.FailNow()
+	}
+	if sx.FromJsonInterface(`{"a":1}`, &set).Ok() {
+		t.FailNow()
+	}
+	if sx.ToJson(sx.NewSetFrom(failingJsonKey(1))).Ok() {
+		t.FailNow()
+	}
+}
+
+type failingJsonKey int
+
+func (failingJsonKey) MarshalJSON() ([]byte, error) { return nil, errors.New("cannot marshal") }
+
+func TestJsonWithConcurrentMap(t *testing.T) {
+	var m = sx.NewConcurrentMapFrom(map[string]int{"b": 2, "a": 1})
+	if json := sx.ToJson(m).ValueOrInit(); json != `{"a":1,"b":2}` {
+		t.FailNow()
+	}
+	sx.FromJsonInterface(`{"c":3}`, &m)
+	if m.Length() != 3 || m.Get("c").Value() != 3 {
+		t.FailNow()
+	}
+	if sx.FromJsonInterface(`[3]`, &m).Ok() {
+		t.FailNow()
+	}
+}
+
+func TestJsonWithDeque(t *testing.T) {
+	var d = sx.NewDequeFrom(2, 3)
+	d.PushFront(1)
+	if json := sx.ToJson(d).ValueOrInit(); json != `[1,2,3]` {
+		t.FailNow()
+	}
+	sx.FromJsonInterface(`[4,5]`, &d)
+	if d.Length() != 2 || d.PeekFront().Value() != 4 || d.PeekBack().Value() != 5 {
+		t.FailNow()
+	}
+	if sx.FromJsonInterface(`{"a":1}`, &d).Ok() {
+		t.FailNow()
+	}
+}
+
+type jsonOptionalTestStruct struct {
+	A sx.Optional[int]
+	B sx.Optional[string]
+}
+
+func TestJsonWithOptional(t *testing.T) {
+	if json := sx.ToJson(sx.NewOptionalFrom(42)).ValueOrInit(); json != `42` {
+		t.FailNow()
+	}
+	if json := sx.ToJson(jsonOptionalTestStruct{A: sx.NewOptionalFrom(1)}).ValueOrInit(); json != `{"A":1,"B":null}` {
+		t.FailNow()
+	}
+
+	var obj = sx.FromJson[jsonOptionalTestStruct](`{"A":2}`).Value()
+	if obj.A.Value() != 2 || obj.B.Ok() {
+		t.FailNow()
+	}
+	obj = sx.FromJson[jsonOptionalTestStruct](`{"A":null,"B":"b"}`).Value()
+	if obj.A.Ok() || obj.B.Value() != "b" {
+		t.FailNow()
+	}
+	var opt = sx.NewOptionalFrom(1)
+	if sx.FromJsonInterface(`null`, &opt); opt.Ok() {
+		t.FailNow()
+	}
+	if sx.FromJson[jsonOptionalTestStruct](`{"A":"x"}`).Ok() {
+		t.FailNow()
+	}
+}
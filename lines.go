@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+var _ Iterator[int, string] = &LineReader{}
+var _ io.Closer = &LineReader{}
+var _ io.Closer = &LineWriter{}
+
+// Options for reading and writing lines, the zero value uses the defaults
+type LineOptions struct {
+	MaxLineLength int    // in bytes, longer lines stop the reader with an error. Default is 1 MiB
+	StripBOM      bool   // removes a UTF-8 byte order mark at the start of the content
+	LineEnding    string // written after each line by the LineWriter. Default is "\n"
+}
+
+const defaultMaxLineLength = 1 << 20
+
+func lineOptions(options []LineOptions) LineOptions {
+	var result LineOptions
+	if len(options) > 0 {
+		result = options[0]
+	}
+	if result.MaxLineLength <= 0 {
+		result.MaxLineLength = defaultMaxLineLength
+	}
+	if result.LineEnding == "" {
+		result.LineEnding = "\n"
+	}
+	return result
+}
+
+// Opens a file for reading it line by line, see LineReader
+func (dir Dir) ReadLines(fromFileName string, options ...LineOptions) Result[*LineReader] {
+	var file, err = dir.FileSystem().OpenFile(StrCat(dir.String(), fromFileName), os.O_RDONLY, 0)
+	if err != nil {
+		return NewResultFromError[*LineReader](err)
+	}
+	return NewResultFrom(NewLineReader(file, options...))
+}
+
+// Creates (or truncates) a file for writing it line by line, see LineWriter
+func (dir Dir) WriteLines(toFileName string, options ...LineOptions) Result[*LineWriter] {
+	var file, err = dir.FileSystem().OpenFile(StrCat(dir.String(), toFileName), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileOptions(nil).FileMode)
+	if err != nil {
+		return NewResultFromError[*LineWriter](err)
+	}
+	return NewResultFrom(NewLineWriter(file, options...))
+}
+
+// Opens the file for reading it line by line, see Dir.ReadLines
+func (file File) ReadLines(options ...LineOptions) Result[*LineReader] {
+	return file.Dir.ReadLines(file.Name, options...)
+}
+
+// Creates (or truncates) the file for writing it line by line, see Dir.WriteLines
+func (file File) WriteLines(options ...LineOptions) Result[*LineWriter] {
+	return file.Dir.WriteLines(file.Name, options...)
+}
+
+// Iterates the lines of a reader without loading all of it into memory
+//
+// The keys are the line numbers (starting at 1), the values are the lines without line endings.
+// "\r\n", "\n" and a single "\r" are line endings on every platform.
+// The iteration stops at the end or at the first error, check Err afterwards.
+// The reader is closed at the end (if it is an io.Closer), call Close when stopping early
+type LineReader struct {
+	reader   io.Reader
+	scanner  *bufio.Scanner
+	stripBOM bool
+	number   int
+	line     string
+	ok       bool
+	closed   bool
+	err      error
+}
+
+// Creates a line reader for any io.Reader
+func NewLineReader(reader io.Reader, options ...LineOptions) *LineReader {
+	var opts = lineOptions(options)
+	var scanner = bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, min(opts.MaxLineLength+2, bufio.MaxScanTokenSize)), opts.MaxLineLength+2) // +2 for "\r\n"
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		var advance, line, err = scanLines(data, atEOF)
+		if len(line) > opts.MaxLineLength {
+			return 0, nil, bufio.ErrTooLong
+		}
+		return advance, line, err
+	})
+	var lr = &LineReader{reader: reader, scanner: scanner, stripBOM: opts.StripBOM}
+	lr.Next()
+	return lr
+}
+
+func (lr *LineReader) Ok() bool      { return lr.ok }
+func (lr *LineReader) Key() int      { return lr.number }
+func (lr *LineReader) Value() string { return lr.line }
+
+func (lr *LineReader) Next() {
+	lr.ok = lr.err == nil && lr.scanner.Scan()
+	if !lr.ok {
+		if err := lr.scanner.Err(); err != nil && lr.err == nil {
+			lr.err = WrapError(err, "Fatal error: cannot read line", Str(lr.number+1))
+		}
+		lr.Close()
+		return
+	}
+	lr.number++
+	lr.line = lr.scanner.Text()
+	if lr.number == 1 && lr.stripBOM {
+		lr.line = strings.TrimPrefix(lr.line, "\uFEFF")
+	}
+}
+
+// The error that stopped the iteration, nil if the end was reached
+func (lr *LineReader) Err() error {
+	return lr.err
+}
+
+// Stops the iteration and closes the reader (if it is an io.Closer), calling it more than once does nothing
+func (lr *LineReader) Close() error {
+	lr.ok = false
+	if lr.closed {
+		return nil
+	}
+	lr.closed = true
+	if closer, ok := lr.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Like bufio.ScanLines, but also splits at a single '\r'
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	var end = bytes.IndexAny(data, "\r\n")
+	switch {
+	case end < 0 && atEOF && len(data) > 0:
+		return len(data), data, nil
+	case end < 0:
+		return 0, nil, nil
+	case data[end] == '\n':
+		return end + 1, data[:end], nil
+	case end+1 < len(data) && data[end+1] == '\n':
+		return end + 2, data[:end], nil
+	case end+1 < len(data) || atEOF:
+		return end + 1, data[:end], nil
+	}
+	return 0, nil, nil // need more data to decide between "\r" and "\r\n"
+}
+
+// Writes lines through a buffer, call Close (or at least Flush) when done
+type LineWriter struct {
+	writer     io.Writer
+	buffer     *bufio.Writer
+	lineEnding string
+}
+
+// Creates a line writer for any io.Writer
+func NewLineWriter(writer io.Writer, options ...LineOptions) *LineWriter {
+	return &LineWriter{writer: writer, buffer: bufio.NewWriter(writer), lineEnding: lineOptions(options).LineEnding}
+}
+
+// Writes the values and the line ending
+func (lw *LineWriter) WriteLine(values ...string) error {
+	var _, err = lw.buffer.WriteString(StrCat(StrCat(values...), lw.lineEnding))
+	return err
+}
+
+// Writes the buffered lines to the underlying writer
+func (lw *LineWriter) Flush() error {
+	return lw.buffer.Flush()
+}
+
+// Flushes and closes the underlying writer (if it is an io.Closer)
+func (lw *LineWriter) Close() error {
+	var errs = NewErrorList(lw.Flush())
+	if closer, ok := lw.writer.(io.Closer); ok {
+		errs.Add(closer.Close())
+	}
+	return errs.Err()
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func readLines(lr *sx.LineReader) []string {
+	var lines = []string{}
+	for ; lr.Ok(); lr.Next() {
+		if lr.Key() != len(lines)+1 {
+			sx.Throw("wrong line number")
+		}
+		lines = append(lines, lr.Value())
+	}
+	return lines
+}
+
+func TestLineReader(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected []string
+	}{
+		{"", []string{}},
+		{"a", []string{"a"}},
+		{"a\n", []string{"a"}},
+		{"a\r\nb\nc\rd", []string{"a", "b", "c", "d"}},
+		{"a\r\rb\r", []string{"a", "", "b"}},
+		{"\n\n", []string{"", ""}},
+	}
+	for _, test := range tests {
+		var lr = sx.NewLineReader(strings.NewReader(test.input))
+		if lines := readLines(lr); strings.Join(lines, "|") != strings.Join(test.expected, "|") || len(lines) != len(test.expected) || lr.Err() != nil {
+			t.Fatal(test.input)
+		}
+		// "\r" and "\n" may end up in different reads
+		lr = sx.NewLineReader(iotest.OneByteReader(strings.NewReader(test.input)))
+		if lines := readLines(lr); strings.Join(lines, "|") != strings.Join(test.expected, "|") || len(lines) != len(test.expected) || lr.Err() != nil {
+			t.Fatal(test.input)
+		}
+	}
+}
+
+func TestLineReaderBOM(t *testing.T) {
+	var input = "\uFEFFfirst\n\uFEFFsecond"
+	if lines := readLines(sx.NewLineReader(strings.NewReader(input))); lines[0] != "\uFEFFfirst" {
+		t.FailNow()
+	}
+	if lines := readLines(sx.NewLineReader(strings.NewReader(input), sx.LineOptions{StripBOM: true})); lines[0] != "first" || lines[1] != "\uFEFFsecond" {
+		t.FailNow()
+	}
+}
+
+func TestLineReaderMaxLineLength(t *testing.T) {
+	var lr = sx.NewLineReader(strings.NewReader("1234\r\n12345\n123456\n"), sx.LineOptions{MaxLineLength: 5})
+	if lines := readLines(lr); len(lines) != 2 || !errors.Is(lr.Err(), bufio.ErrTooLong) || !strings.Contains(lr.Err().Error(), "line 3") {
+		t.FailNow()
+	}
+	lr = sx.NewLineReader(strings.NewReader(strings.Repeat("x", 100)), sx.LineOptions{MaxLineLength: 5})
+	if lr.Ok() || !errors.Is(lr.Err(), bufio.ErrTooLong) {
+		t.FailNow()
+	}
+}
+
+func TestLineReaderErrors(t *testing.T) {
+	var failure = errors.New("failure")
+	var lr = sx.NewLineReader(io.MultiReader(strings.NewReader("a\nb"), iotest.ErrReader(failure)))
+	if lines := readLines(lr); len(lines) != 2 || !errors.Is(lr.Err(), failure) {
+		t.FailNow()
+	}
+	var dir = sx.NewDirFromString(t.TempDir())
+	if result := dir.ReadLines("missing.txt"); result.Ok() || !errors.Is(result.Err(), fs.ErrNotExist) {
+		t.FailNow()
+	}
+	if result := dir.WriteLines("missing/file.txt"); result.Ok() {
+		t.FailNow()
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed int
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestLineReaderClose(t *testing.T) {
+	var reader = &closeRecorder{Reader: strings.NewReader("a\nb\nc")}
+	var lr = sx.NewLineReader(reader)
+	if !lr.Ok() || lr.Value() != "a" || reader.closed != 0 {
+		t.FailNow()
+	}
+	sx.ThrowIfError(lr.Close())
+	sx.ThrowIfError(lr.Close())
+	if lr.Ok() || reader.closed != 1 {
+		t.FailNow()
+	}
+	reader = &closeRecorder{Reader: strings.NewReader("a\nb\nc")}
+	if lines := readLines(sx.NewLineReader(reader)); len(lines) != 3 || reader.closed != 1 {
+		t.FailNow()
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var dir = sx.NewDirFromString(t.TempDir())
+	var lw = dir.WriteLines("lines.txt", sx.LineOptions{LineEnding: "\r\n"}).Value()
+	for i := 1; i <= 1000; i++ {
+		sx.ThrowIfError(lw.WriteLine("line ", sx.Str(i)))
+	}
+	sx.ThrowIfError(lw.Close())
+	var text = dir.ReadAllBytes("lines.txt").Value()
+	if !strings.HasPrefix(string(text), "line 1\r\nline 2\r\n") || !strings.HasSuffix(string(text), "line 1000\r\n") {
+		t.FailNow()
+	}
+
+	var lr = dir.File("lines.txt").ReadLines().Value()
+	var count = 0
+	for ; lr.Ok(); lr.Next() {
+		if lr.Value() != sx.StrCat("line ", sx.Str(lr.Key())) {
+			t.Fatal(lr.Key())
+		}
+		count = lr.Key()
+	}
+	if count != 1000 || lr.Err() != nil {
+		t.FailNow()
+	}
+
+	lw = dir.File("lines.txt").WriteLines().Value()
+	sx.ThrowIfError(lw.WriteLine())
+	sx.ThrowIfError(lw.WriteLine("a", "b"))
+	sx.ThrowIfError(lw.Close())
+	if string(dir.ReadAllBytes("lines.txt").Value()) != "\nab\n" {
+		t.FailNow()
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("failure") }
+
+func TestLineWriterErrors(t *testing.T) {
+	var lw = sx.NewLineWriter(failingWriter{})
+	sx.ThrowIfError(lw.WriteLine("buffered"))
+	if lw.Flush() == nil || lw.WriteLine("x") == nil || lw.Close() == nil {
+		t.FailNow()
+	}
+	var builder strings.Builder
+	lw = sx.NewLineWriter(&builder)
+	sx.ThrowIfError(lw.WriteLine("a"))
+	if builder.Len() != 0 {
+		t.FailNow()
+	}
+	sx.ThrowIfError(lw.Flush())
+	if builder.String() != "a\n" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ LogSink = &logWriterSink{}
+var _ LogSink = &LogMemorySink{}
+
+// Severity of a log entry, a logger only writes entries at or above its level
+type LogLevel int
+
+const (
+	LogLevelTrace LogLevel = iota
+	LogLevelDebug
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+	LogLevelOff // disables logging when used as level
+)
+
+var logLevelNames = [...]string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "OFF"}
+
+func (level LogLevel) String() string {
+	if level < LogLevelTrace || level > LogLevelOff {
+		return Str("LogLevel(", int(level), ")")
+	}
+	return logLevelNames[level]
+}
+
+// Parses a level name like "info" or "WARN", case-insensitive
+func ParseLogLevel(s string) Result[LogLevel] {
+	for level, name := range logLevelNames {
+		if strings.EqualFold(s, name) {
+			return NewResultFrom(LogLevel(level))
+		}
+	}
+	return NewResultError[LogLevel]("Fatal error: unknown log level '", s, "'")
+}
+
+// Output format of the log sinks
+type LogFormat int
+
+const (
+	LogFormatText LogFormat = iota // one line per entry: time, level, function, message and fields
+	LogFormatJson                  // one json object per line
+)
+
+// A single log message with its structured key/value fields
+type LogEntry struct {
+	Time     time.Time
+	Level    LogLevel
+	Function string // the function that logged the entry, see ReflectFunctionName
+	Message  string
+	Fields   Map[string, any] // in the order they were given
+}
+
+// Formats the entry as a single line, without the trailing newline
+func (entry LogEntry) Format(format LogFormat) string {
+	if format == LogFormatJson {
+		return entry.formatJson()
+	}
+	var sb = NewStringBuilder()
+	sb.WriteStrings(entry.Time.Format("2006-01-02T15:04:05.000Z07:00"), " ", entry.Level.String(), " ", entry.Function, ": ", entry.Message)
+	for it := entry.Fields.NewIterator(); it.Ok(); it.Next() {
+		var value = Str(it.Value())
+		if value == "" || strings.ContainsAny(value, " \t\r\n\"=") {
+			value = strconv.Quote(value)
+		}
+		sb.WriteStrings(" ", it.Key(), "=", value)
+	}
+	return sb.String()
+}
+
+func (entry LogEntry) formatJson() string {
+	var object = NewOrderedMapFrom[string, any](
+		NewPair[string, any]("time", entry.Time.Format(time.RFC3339Nano)),
+		NewPair[string, any]("level", entry.Level.String()),
+		NewPair[string, any]("function", entry.Function),
+		NewPair[string, any]("message", entry.Message),
+	)
+	for it := entry.Fields.NewIterator(); it.Ok(); it.Next() {
+		var value = it.Value()
+		if _, err := json.Marshal(value); err != nil {
+			value = Str(value)
+		}
+		object.Put(it.Key(), value)
+	}
+	return ToJson(object).ValueOrThrow("Fatal error: cannot encode log entry")
+}
+
+// Destination of log entries, e.g. stderr, a file or memory
+type LogSink interface {
+	WriteLog(entry LogEntry) error
+}
+
+// Creates a sink that writes one line per entry to the writer, e.g. os.Stdout
+//
+// If the writer is an io.Closer, Logger.Close closes it
+func NewLogWriterSink(writer io.Writer, format LogFormat) LogSink {
+	return &logWriterSink{writer: writer, format: format}
+}
+
+// Creates a sink that writes to stderr, redirections by SetStdErr apply
+func NewLogStdErrSink(format LogFormat) LogSink {
+	return NewLogWriterSink(stdErrWriter{}, format)
+}
+
+// Creates a sink that appends to a file in the directory, the file is created if needed
+func NewLogFileSink(dir Dir, fileName string, format LogFormat) Result[LogSink] {
+	var file, err = dir.FileSystem().OpenFile(StrCat(dir.String(), fileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return NewResultFromError[LogSink](err)
+	}
+	return NewResultFrom(NewLogWriterSink(file, format))
+}
+
+type logWriterSink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	format LogFormat
+}
+
+func (sink *logWriterSink) WriteLog(entry LogEntry) error {
+	var line = StrCat(entry.Format(sink.format), "\n")
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	var _, err = io.WriteString(sink.writer, line)
+	return err
+}
+
+func (sink *logWriterSink) Close() error {
+	if closer, ok := sink.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Keeps all entries in memory, e.g. to check them in tests
+type LogMemorySink struct {
+	mutex   sync.Mutex
+	entries []LogEntry
+}
+
+func NewLogMemorySink() *LogMemorySink {
+	return &LogMemorySink{}
+}
+
+func (sink *LogMemorySink) WriteLog(entry LogEntry) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.entries = append(sink.entries, entry)
+	return nil
+}
+
+// A copy of all entries written so far
+func (sink *LogMemorySink) Entries() Array[LogEntry] {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return NewArrayFrom(sink.entries...)
+}
+
+// The messages of all entries written so far
+func (sink *LogMemorySink) Messages() Array[string] {
+	return MapValues(sink.Entries().NewIterator(), func(_ int, entry LogEntry) Optional[string] { return NewOptionalFrom(entry.Message) })
+}
+
+// Removes all entries
+func (sink *LogMemorySink) Clear() {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.entries = nil
+}
+
+// Leveled logger with structured fields, all methods are goroutine-safe
+//
+// The level works like a stack (see PushLevel and PopLevel).
+// Filters override the level for single functions or whole packages.
+// The sinks are called outside of the logger's lock, so they need to be goroutine-safe themselves
+type Logger struct {
+	mutex   sync.Mutex
+	levels  Array[LogLevel] // the last level is the current one
+	filters Map[string, LogLevel]
+	sinks   Array[LogSink]
+	onError func(err error, entry LogEntry)
+}
+
+// Creates a logger with level Info, writes text to stderr if no sinks are given
+func NewLogger(sinks ...LogSink) *Logger {
+	if len(sinks) == 0 {
+		sinks = []LogSink{NewLogStdErrSink(LogFormatText)}
+	}
+	return &Logger{levels: NewArrayFrom(LogLevelInfo), filters: NewMap[string, LogLevel](), sinks: NewArrayFrom(sinks...), onError: printLogError}
+}
+
+func printLogError(err error, entry LogEntry) {
+	PrintStdErrLn("Fatal error: cannot write log entry '", entry.Message, "': ", err.Error())
+}
+
+// Sets the function that is called when a sink cannot write an entry, by default the error is printed to StdErr
+func (l *Logger) SetErrorHandler(handler func(err error, entry LogEntry)) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.onError = handler
+}
+
+var defaultLogger = NewLogger()
+
+// The logger used by LogTrace, LogDebug, LogInfo, LogWarn and LogError
+func DefaultLogger() *Logger {
+	return defaultLogger
+}
+
+func (l *Logger) Level() LogLevel {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.levels.Peek().Value()
+}
+
+// Replaces the current level
+func (l *Logger) SetLevel(level LogLevel) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.levels.Pop()
+	l.levels.Push(level)
+}
+
+// Sets a new level, the previous one is restored by PopLevel
+func (l *Logger) PushLevel(level LogLevel) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.levels.Push(level)
+}
+
+// Restores the previous level, the initial level is never removed
+func (l *Logger) PopLevel() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.levels.Length() > 1 {
+		l.levels.Pop()
+	}
+}
+
+// Overrides the level for a function or package, e.g. "github.com/ZeroBsd/sx" or "main.parseConfig"
+//
+// Names are matched like ReflectFunctionName returns them, the longest matching filter wins
+func (l *Logger) SetFilter(functionOrPackage string, level LogLevel) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.filters.Put(functionOrPackage, level)
+}
+
+func (l *Logger) RemoveFilter(functionOrPackage string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.filters.Drop(functionOrPackage)
+}
+
+func (l *Logger) AddSink(sink LogSink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sinks.Push(sink)
+}
+
+// Replaces all sinks, the old sinks are not closed
+func (l *Logger) SetSinks(sinks ...LogSink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sinks = NewArrayFrom(sinks...)
+}
+
+// Closes all sinks that are an io.Closer (e.g. file sinks) and removes all sinks
+func (l *Logger) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var errs = NewErrorList()
+	for it := l.sinks.NewIterator(); it.Ok(); it.Next() {
+		if closer, ok := it.Value().(io.Closer); ok {
+			errs.Add(closer.Close())
+		}
+	}
+	l.sinks = NewArray[LogSink]()
+	return errs.Err()
+}
+
+// Checks if an entry of this level from the function would be written
+func (l *Logger) IsEnabled(level LogLevel, function string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.isEnabled(level, function)
+}
+
+func (l *Logger) isEnabled(level LogLevel, function string) bool {
+	var threshold = l.levels.Peek().Value()
+	var bestMatch = -1
+	for it := l.filters.NewIterator(); it.Ok(); it.Next() {
+		var name = it.Key()
+		if len(name) > bestMatch && (function == name || strings.HasPrefix(function, name+".") || strings.HasPrefix(function, name+"/")) {
+			bestMatch = len(name)
+			threshold = it.Value()
+		}
+	}
+	return level != LogLevelOff && level >= threshold
+}
+
+// Writes an entry with key/value fields, e.g. Log(LogLevelInfo, "user logged in", "user", name, "attempt", 2)
+func (l *Logger) Log(level LogLevel, message string, keyValues ...any) {
+	l.log(level, message, keyValues)
+}
+
+func (l *Logger) Trace(message string, keyValues ...any) { l.log(LogLevelTrace, message, keyValues) }
+func (l *Logger) Debug(message string, keyValues ...any) { l.log(LogLevelDebug, message, keyValues) }
+func (l *Logger) Info(message string, keyValues ...any)  { l.log(LogLevelInfo, message, keyValues) }
+func (l *Logger) Warn(message string, keyValues ...any)  { l.log(LogLevelWarn, message, keyValues) }
+func (l *Logger) Error(message string, keyValues ...any) { l.log(LogLevelError, message, keyValues) }
+
+func LogTrace(message string, keyValues ...any) { defaultLogger.log(LogLevelTrace, message, keyValues) }
+func LogDebug(message string, keyValues ...any) { defaultLogger.log(LogLevelDebug, message, keyValues) }
+func LogInfo(message string, keyValues ...any)  { defaultLogger.log(LogLevelInfo, message, keyValues) }
+func LogWarn(message string, keyValues ...any)  { defaultLogger.log(LogLevelWarn, message, keyValues) }
+func LogError(message string, keyValues ...any) { defaultLogger.log(LogLevelError, message, keyValues) }
+
+// Must be called directly by the exported logging functions, so the caller's function name is found
+func (l *Logger) log(level LogLevel, message string, keyValues []any) {
+	l.write(ReflectFunctionName(2), level, false, message, keyValues)
+}
+
+// Forced entries are written regardless of the level, see Debug
+func (l *Logger) write(function string, level LogLevel, force bool, message string, keyValues []any) {
+	l.mutex.Lock()
+	if !force && !l.isEnabled(level, function) {
+		l.mutex.Unlock()
+		return
+	}
+	var sinks, onError = slices.Clone(l.sinks.SubSlice()), l.onError
+	l.mutex.Unlock()
+
+	var entry = LogEntry{Time: time.Now(), Level: level, Function: function, Message: message, Fields: NewOrderedMap[string, any]()}
+	for i := 0; i < len(keyValues); i += 2 {
+		if i+1 == len(keyValues) {
+			entry.Fields.Put("!BADKEY", keyValues[i])
+		} else {
+			entry.Fields.Put(Str(keyValues[i]), keyValues[i+1])
+		}
+	}
+	for _, sink := range sinks {
+		if err := sink.WriteLog(entry); err != nil && onError != nil {
+			onError(err, entry)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestLogLevels(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	var logger = sx.NewLogger(sink)
+	if logger.Level() != sx.LogLevelInfo {
+		t.FailNow()
+	}
+	logger.Trace("trace")
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Log(sx.LogLevelOff, "off")
+	if strings.Join(sink.Messages().SubSlice(), ",") != "info,warn,error" {
+		t.FailNow()
+	}
+
+	sink.Clear()
+	logger.PushLevel(sx.LogLevelTrace)
+	logger.Trace("trace")
+	logger.PushLevel(sx.LogLevelOff)
+	logger.Error("error")
+	logger.PopLevel()
+	logger.Debug("debug")
+	logger.PopLevel()
+	logger.PopLevel()
+	logger.Debug("debug")
+	logger.SetLevel(sx.LogLevelWarn)
+	logger.Info("info")
+	logger.Warn("warn")
+	if strings.Join(sink.Messages().SubSlice(), ",") != "trace,debug,warn" || logger.Level() != sx.LogLevelWarn {
+		t.FailNow()
+	}
+
+	if sx.ParseLogLevel("warn").Value() != sx.LogLevelWarn || sx.ParseLogLevel("verbose").Ok() {
+		t.FailNow()
+	}
+	if sx.LogLevelTrace.String() != "TRACE" || sx.LogLevel(42).String() != "LogLevel(42)" {
+		t.FailNow()
+	}
+}
+
+func logFromHelper(logger *sx.Logger) {
+	logger.Debug("from helper")
+}
+
+func TestLogFilters(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	var logger = sx.NewLogger(sink)
+	logger.SetFilter("github.com/ZeroBsd/sx_test", sx.LogLevelError)
+	logger.SetFilter("github.com/ZeroBsd/sx_test.logFromHelper", sx.LogLevelDebug)
+	logger.Warn("filtered by package")
+	logFromHelper(logger)
+	if strings.Join(sink.Messages().SubSlice(), ",") != "from helper" {
+		t.FailNow()
+	}
+	if entry := sink.Entries().Get(0).Value(); entry.Function != "github.com/ZeroBsd/sx_test.logFromHelper" || entry.Level != sx.LogLevelDebug {
+		t.FailNow()
+	}
+	if !logger.IsEnabled(sx.LogLevelInfo, "main.main") || logger.IsEnabled(sx.LogLevelWarn, "github.com/ZeroBsd/sx_test.TestLogFilters") {
+		t.FailNow()
+	}
+	logger.RemoveFilter("github.com/ZeroBsd/sx_test")
+	logger.Warn("not filtered")
+	if sink.Entries().Length() != 2 {
+		t.FailNow()
+	}
+}
+
+func TestLogEntryFormat(t *testing.T) {
+	var entry = sx.LogEntry{
+		Time:     time.Date(2024, 5, 6, 7, 8, 9, 10_000_000, time.UTC),
+		Level:    sx.LogLevelWarn,
+		Function: "main.run",
+		Message:  "disk almost full",
+		Fields:   sx.NewOrderedMapFrom[string, any](sx.NewPair[string, any]("free", 42), sx.NewPair[string, any]("path", "/my data"), sx.NewPair[string, any]("f", func() {})),
+	}
+	if text := entry.Format(sx.LogFormatText); !strings.HasPrefix(text, `2024-05-06T07:08:09.010Z WARN main.run: disk almost full free=42 path="/my data" f=0x`) {
+		t.Fatal(text)
+	}
+	if json := entry.Format(sx.LogFormatJson); !strings.HasPrefix(json, `{"time":"2024-05-06T07:08:09.01Z","level":"WARN","function":"main.run","message":"disk almost full","free":42,"path":"/my data","f":"0x`) {
+		t.Fatal(json)
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	var logger = sx.NewLogger(sink)
+	logger.Info("login", "user", "bob", 2, "second", "dangling")
+	var fields = sink.Entries().Get(0).Value().Fields
+	if fields.Get("user").Value() != "bob" || fields.Get("2").Value() != "second" || fields.Get("!BADKEY").Value() != "dangling" {
+		t.FailNow()
+	}
+}
+
+func TestLogFileSink(t *testing.T) {
+	var dir = sx.NewDirFromString(t.TempDir())
+	var sink = sx.NewLogFileSink(dir, "test.log", sx.LogFormatJson).Value()
+	var logger = sx.NewLogger(sink, sx.NewLogWriterSink(&strings.Builder{}, sx.LogFormatText))
+	logger.Info("first", "n", 1)
+	logger.Info("second")
+	if err := logger.Close(); err != nil {
+		t.FailNow()
+	}
+	logger.Error("nothing is written after close")
+	var lines = strings.Split(dir.ReadAllText("test.log").Value(), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], `"message":"first","n":1}`) || !strings.Contains(lines[1], `"message":"second"}`) {
+		t.FailNow()
+	}
+	if sx.NewLogFileSink(dir, "missing/test.log", sx.LogFormatText).Ok() {
+		t.FailNow()
+	}
+}
+
+type failingCloser struct{ strings.Builder }
+
+func (*failingCloser) Close() error { return errors.New("cannot close") }
+
+func TestLoggerCloseErrors(t *testing.T) {
+	var logger = sx.NewLogger()
+	logger.SetSinks(sx.NewLogWriterSink(&failingCloser{}, sx.LogFormatText))
+	if logger.Close() == nil {
+		t.FailNow()
+	}
+}
+
+func TestDefaultLogger(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	var logger = sx.DefaultLogger()
+	logger.AddSink(sink)
+	logger.PushLevel(sx.LogLevelTrace)
+	defer logger.PopLevel()
+	logger.PushLevel(sx.LogLevelOff)
+	sx.LogTrace("trace")
+	sx.LogDebug("debug")
+	sx.LogInfo("info")
+	sx.LogWarn("warn")
+	sx.LogError("error")
+	logger.PopLevel()
+	logger.SetFilter("github.com/ZeroBsd/sx_test.TestDefaultLogger", sx.LogLevelTrace)
+	defer logger.RemoveFilter("github.com/ZeroBsd/sx_test.TestDefaultLogger")
+	logger.SetSinks(sink)
+	defer logger.SetSinks(sx.NewLogStdErrSink(sx.LogFormatText))
+	sx.LogTrace("trace")
+	sx.LogDebug("debug")
+	sx.LogInfo("info")
+	sx.LogWarn("warn")
+	sx.LogError("error")
+	if strings.Join(sink.Messages().SubSlice(), ",") != "trace,debug,info,warn,error" {
+		t.FailNow()
+	}
+	if sink.Entries().Get(0).Value().Function != "github.com/ZeroBsd/sx_test.TestDefaultLogger" {
+		t.FailNow()
+	}
+}
+
+func TestLoggerConcurrency(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	var logger = sx.NewLogger(sink)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.PushLevel(sx.LogLevelInfo)
+				logger.Info("message", "j", j)
+				logger.PopLevel()
+			}
+		}()
+	}
+	wg.Wait()
+	if sink.Entries().Length() != 800 {
+		t.FailNow()
+	}
+}
+
+type failingSink struct{}
+
+func (failingSink) WriteLog(entry sx.LogEntry) error { return errors.New("disk full") }
+
+func TestLogSinkErrors(t *testing.T) {
+	var sink = sx.NewLogMemorySink()
+	var logger = sx.NewLogger(failingSink{}, sink)
+	var failed = []string{}
+	logger.SetErrorHandler(func(err error, entry sx.LogEntry) { failed = append(failed, entry.Message+": "+err.Error()) })
+	logger.Info("first")
+	logger.Warn("second")
+	if strings.Join(failed, ",") != "first: disk full,second: disk full" || sink.Entries().Length() != 2 {
+		t.Fatal(failed)
+	}
+
+	var stderr strings.Builder
+	defer sx.SetStdErr(&stderr)()
+	sx.NewLogger(failingSink{}).Error("lost")
+	if stderr.String() != "Fatal error: cannot write log entry 'lost': disk full\n" {
+		t.Fatal(stderr.String())
+	}
+}
+
+type loggingSink struct {
+	logger *sx.Logger
+	levels []sx.LogLevel
+}
+
+func (s *loggingSink) WriteLog(entry sx.LogEntry) error {
+	s.levels = append(s.levels, s.logger.Level()) // would deadlock if sinks were called under the logger's lock
+	return nil
+}
+
+func TestLogSinkCalledOutsideLock(t *testing.T) {
+	var sink = &loggingSink{}
+	sink.logger = sx.NewLogger(sink)
+	sink.logger.Info("message")
+	if len(sink.levels) != 1 || sink.levels[0] != sx.LogLevelInfo {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,407 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ FileSystem = &MemoryFileSystem{}
+var _ FileSystemFile = &memoryFile{}
+var _ fs.FileInfo = memoryFileInfo{}
+
+// The operations of a MemoryFileSystem that can be made to fail, see MemoryFileSystem.FailNth
+type FileOperation string
+
+const (
+	FileOperationOpen    FileOperation = "open" // also counts CreateTemp
+	FileOperationRead    FileOperation = "read"
+	FileOperationWrite   FileOperation = "write"
+	FileOperationClose   FileOperation = "close"
+	FileOperationSync    FileOperation = "sync"
+	FileOperationChmod   FileOperation = "chmod"
+	FileOperationStat    FileOperation = "stat"
+	FileOperationReadDir FileOperation = "readdir"
+	FileOperationMkdir   FileOperation = "mkdir"
+	FileOperationRemove  FileOperation = "remove"
+	FileOperationRename  FileOperation = "rename"
+)
+
+var errIsDirectory = errors.New("is a directory")
+var errNotDirectory = errors.New("not a directory")
+var errDirectoryNotEmpty = errors.New("directory not empty")
+var errNotOpenForReading = errors.New("file not open for reading")
+var errNotOpenForWriting = errors.New("file not open for writing")
+
+// A file system that only lives in memory, e.g. for tests. All methods are goroutine-safe
+//
+// Paths are handled like by NewFileSystemFromFS, so "/a/b.txt", "a/b.txt" and "C:\a\b.txt" are the same file.
+// There are no symlinks and the modes are only stored, not checked
+type MemoryFileSystem struct {
+	mutex     sync.Mutex
+	nodes     map[string]*memoryNode // keyed by the io/fs path, "." is the root
+	calls     map[FileOperation]int
+	faults    map[FileOperation]memoryFault
+	tempCount int
+}
+
+type memoryNode struct {
+	content []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+type memoryFault struct {
+	call int
+	err  error
+}
+
+// Creates an empty file system, only the root directory exists
+func NewMemoryFileSystem() *MemoryFileSystem {
+	var root = &memoryNode{mode: fs.ModeDir | 0755, modTime: time.Now()}
+	return &MemoryFileSystem{
+		nodes:  map[string]*memoryNode{".": root},
+		calls:  map[FileOperation]int{},
+		faults: map[FileOperation]memoryFault{},
+	}
+}
+
+// Makes the nth call of the operation fail with the error, counted from now and starting at 1
+//
+// E.g. 'FailNth(sx.FileOperationWrite, 1, err)' fails the next write. Each operation has at most one pending fault
+func (m *MemoryFileSystem) FailNth(operation FileOperation, n int, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.faults[operation] = memoryFault{call: m.calls[operation] + n, err: err}
+}
+
+// The number of calls of the operation so far, including the failed ones
+func (m *MemoryFileSystem) Calls(operation FileOperation) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.calls[operation]
+}
+
+// Counts the call and returns the injected error, if it is due. The mutex must be held
+func (m *MemoryFileSystem) fault(operation FileOperation, name string) error {
+	m.calls[operation]++
+	var fault, ok = m.faults[operation]
+	if !ok || fault.call != m.calls[operation] {
+		return nil
+	}
+	delete(m.faults, operation)
+	return &fs.PathError{Op: string(operation), Path: name, Err: fault.err}
+}
+
+// The node of the parent directory, if it exists. The mutex must be held
+func (m *MemoryFileSystem) parent(key string) (*memoryNode, error) {
+	var parent = m.nodes[path.Dir(key)]
+	if parent == nil {
+		return nil, fs.ErrNotExist
+	}
+	if !parent.mode.IsDir() {
+		return nil, errNotDirectory
+	}
+	return parent, nil
+}
+
+func (m *MemoryFileSystem) OpenFile(name string, flag int, perm fs.FileMode) (FileSystemFile, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationOpen, name); err != nil {
+		return nil, err
+	}
+	var key = fsPath(name)
+	var node = m.nodes[key]
+	switch {
+	case node == nil && flag&os.O_CREATE == 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case node == nil:
+		if _, err := m.parent(key); err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		node = &memoryNode{mode: perm.Perm(), modTime: time.Now()}
+		m.nodes[key] = node
+	case flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	case node.mode.IsDir() && flag&fsWriteFlags != 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errIsDirectory}
+	case flag&os.O_TRUNC != 0:
+		node.content = nil
+		node.modTime = time.Now()
+	}
+	return &memoryFile{fsys: m, name: name, node: node, flag: flag}, nil
+}
+
+// Like os.CreateTemp, the last "*" in the pattern is replaced by a unique number
+func (m *MemoryFileSystem) CreateTemp(dir string, pattern string) (FileSystemFile, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationOpen, filepath.Join(dir, pattern)); err != nil {
+		return nil, err
+	}
+	for {
+		m.tempCount++
+		var name = StrCat(pattern, Str(m.tempCount))
+		if index := strings.LastIndex(pattern, "*"); index >= 0 {
+			name = StrCat(pattern[:index], Str(m.tempCount), pattern[index+1:])
+		}
+		name = filepath.Join(dir, name)
+		var key = fsPath(name)
+		if m.nodes[key] != nil {
+			continue
+		}
+		if _, err := m.parent(key); err != nil {
+			return nil, &fs.PathError{Op: "createtemp", Path: name, Err: err}
+		}
+		var node = &memoryNode{mode: 0600, modTime: time.Now()}
+		m.nodes[key] = node
+		return &memoryFile{fsys: m, name: name, node: node, flag: os.O_RDWR}, nil
+	}
+}
+
+func (m *MemoryFileSystem) Stat(name string) (fs.FileInfo, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationStat, name); err != nil {
+		return nil, err
+	}
+	var key = fsPath(name)
+	var node = m.nodes[key]
+	if node == nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return node.info(path.Base(key)), nil
+}
+
+func (m *MemoryFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationReadDir, name); err != nil {
+		return nil, err
+	}
+	var key = fsPath(name)
+	var node = m.nodes[key]
+	if node == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if !node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: errNotDirectory}
+	}
+	var entries = []fs.DirEntry{}
+	for childKey, child := range m.nodes {
+		if childKey != key && path.Dir(childKey) == key {
+			entries = append(entries, fs.FileInfoToDirEntry(child.info(path.Base(childKey))))
+		}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
+
+func (m *MemoryFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationMkdir, name); err != nil {
+		return err
+	}
+	var key = fsPath(name)
+	if key == "." {
+		return nil
+	}
+	var current = ""
+	for _, segment := range strings.Split(key, "/") {
+		current = path.Join(current, segment)
+		var node = m.nodes[current]
+		if node == nil {
+			m.nodes[current] = &memoryNode{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+		} else if !node.mode.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: name, Err: errNotDirectory}
+		}
+	}
+	return nil
+}
+
+// Removes a file or an empty directory
+func (m *MemoryFileSystem) Remove(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationRemove, name); err != nil {
+		return err
+	}
+	var key = fsPath(name)
+	if m.nodes[key] == nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if key == "." || len(m.descendants(key)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: errDirectoryNotEmpty}
+	}
+	delete(m.nodes, key)
+	return nil
+}
+
+// Moves a file or a directory including its content, an existing file (or empty directory) is replaced
+func (m *MemoryFileSystem) Rename(oldPath string, newPath string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.fault(FileOperationRename, oldPath); err != nil {
+		return err
+	}
+	var linkError = func(err error) error {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: err}
+	}
+	var oldKey, newKey = fsPath(oldPath), fsPath(newPath)
+	var node, target = m.nodes[oldKey], m.nodes[newKey]
+	if node == nil {
+		return linkError(fs.ErrNotExist)
+	}
+	if oldKey == newKey {
+		return nil
+	}
+	if _, err := m.parent(newKey); err != nil {
+		return linkError(err)
+	}
+	switch {
+	case oldKey == "." || strings.HasPrefix(newKey, StrCat(oldKey, "/")):
+		return linkError(fs.ErrInvalid)
+	case target == nil:
+	case target.mode.IsDir() && !node.mode.IsDir():
+		return linkError(errIsDirectory)
+	case !target.mode.IsDir() && node.mode.IsDir():
+		return linkError(errNotDirectory)
+	case len(m.descendants(newKey)) > 0:
+		return linkError(errDirectoryNotEmpty)
+	}
+	for _, key := range append(m.descendants(oldKey), oldKey) {
+		m.nodes[StrCat(newKey, key[len(oldKey):])] = m.nodes[key]
+		delete(m.nodes, key)
+	}
+	return nil
+}
+
+// The keys of all nodes below the directory. The mutex must be held
+func (m *MemoryFileSystem) descendants(key string) []string {
+	var result = []string{}
+	for childKey := range m.nodes {
+		if key == "." && childKey != "." || strings.HasPrefix(childKey, StrCat(key, "/")) {
+			result = append(result, childKey)
+		}
+	}
+	return result
+}
+
+func (node *memoryNode) info(name string) memoryFileInfo {
+	return memoryFileInfo{name: name, size: int64(len(node.content)), mode: node.mode, modTime: node.modTime}
+}
+
+// A snapshot, like the FileInfo of the os package
+type memoryFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (info memoryFileInfo) Name() string       { return info.name }
+func (info memoryFileInfo) Size() int64        { return info.size }
+func (info memoryFileInfo) Mode() fs.FileMode  { return info.mode }
+func (info memoryFileInfo) ModTime() time.Time { return info.modTime }
+func (info memoryFileInfo) IsDir() bool        { return info.mode.IsDir() }
+func (info memoryFileInfo) Sys() any           { return nil }
+
+// An open file, it keeps working on the content after a rename, like on unix
+type memoryFile struct {
+	fsys   *MemoryFileSystem
+	name   string
+	node   *memoryNode
+	flag   int
+	offset int
+	closed bool
+}
+
+func (f *memoryFile) Name() string { return f.name }
+
+// Checks for closed files and injected faults. The mutex must be held
+func (f *memoryFile) check(operation FileOperation) error {
+	if f.closed {
+		return &fs.PathError{Op: string(operation), Path: f.name, Err: fs.ErrClosed}
+	}
+	return f.fsys.fault(operation, f.name)
+}
+
+func (f *memoryFile) Read(bytes []byte) (int, error) {
+	f.fsys.mutex.Lock()
+	defer f.fsys.mutex.Unlock()
+	if err := f.check(FileOperationRead); err != nil {
+		return 0, err
+	}
+	if f.node.mode.IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: errIsDirectory}
+	}
+	if f.flag&os.O_WRONLY != 0 {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: errNotOpenForReading}
+	}
+	if f.offset >= len(f.node.content) {
+		return 0, io.EOF
+	}
+	var n = copy(bytes, f.node.content[f.offset:])
+	f.offset += n
+	return n, nil
+}
+
+func (f *memoryFile) Write(bytes []byte) (int, error) {
+	f.fsys.mutex.Lock()
+	defer f.fsys.mutex.Unlock()
+	if err := f.check(FileOperationWrite); err != nil {
+		return 0, err
+	}
+	if f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: errNotOpenForWriting}
+	}
+	if f.flag&os.O_APPEND != 0 {
+		f.offset = len(f.node.content)
+	}
+	var end = f.offset + len(bytes)
+	if end > len(f.node.content) {
+		f.node.content = append(f.node.content, make([]byte, end-len(f.node.content))...)
+	}
+	copy(f.node.content[f.offset:], bytes)
+	f.offset = end
+	f.node.modTime = time.Now()
+	return len(bytes), nil
+}
+
+func (f *memoryFile) Chmod(mode fs.FileMode) error {
+	f.fsys.mutex.Lock()
+	defer f.fsys.mutex.Unlock()
+	if err := f.check(FileOperationChmod); err != nil {
+		return err
+	}
+	f.node.mode = f.node.mode.Type() | mode.Perm()
+	return nil
+}
+
+func (f *memoryFile) Sync() error {
+	f.fsys.mutex.Lock()
+	defer f.fsys.mutex.Unlock()
+	return f.check(FileOperationSync)
+}
+
+// The file is closed even if an injected fault is returned
+func (f *memoryFile) Close() error {
+	f.fsys.mutex.Lock()
+	defer f.fsys.mutex.Unlock()
+	var err = f.check(FileOperationClose)
+	f.closed = true
+	return err
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func memoryReadDir(memory *sx.MemoryFileSystem, name string) []string {
+	var names = []string{}
+	var entries, err = memory.ReadDir(name)
+	sx.ThrowIfError(err)
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestMemoryFileSystemPaths(t *testing.T) {
+	var memory = sx.NewMemoryFileSystem()
+	sx.ThrowIfError(memory.MkdirAll("/a/b/", 0700))
+	for _, name := range []string{"a", "/a/", "a/b/..", "/../a"} {
+		if info, err := memory.Stat(name); err != nil || !info.IsDir() || info.Name() != "a" || info.Mode().Perm() != 0700 {
+			t.Fatal(name)
+		}
+	}
+	if info, err := memory.Stat("/"); err != nil || !info.IsDir() {
+		t.FailNow()
+	}
+	if names := memoryReadDir(memory, "/"); !reflect.DeepEqual(names, []string{"a"}) {
+		t.Fatal(names)
+	}
+}
+
+func TestMemoryFileSystemFiles(t *testing.T) {
+	var memory = sx.NewMemoryFileSystem()
+	if _, err := memory.OpenFile("/missing", os.O_RDONLY, 0); !errors.Is(err, fs.ErrNotExist) {
+		t.FailNow()
+	}
+	if _, err := memory.OpenFile("/missing/file", os.O_CREATE|os.O_WRONLY, 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.FailNow()
+	}
+
+	var file, err = memory.OpenFile("/file", os.O_CREATE|os.O_RDWR, 0640)
+	sx.ThrowIfError(err)
+	if _, err = io.WriteString(file, "hello world"); err != nil || file.Name() != "/file" {
+		t.FailNow()
+	}
+	if _, err = file.Read(make([]byte, 1)); err != io.EOF {
+		t.FailNow()
+	}
+	sx.ThrowIfError(file.Chmod(0600))
+	sx.ThrowIfError(file.Sync())
+	sx.ThrowIfError(file.Close())
+	if _, err = file.Write([]byte("x")); !errors.Is(err, fs.ErrClosed) || !errors.Is(file.Close(), fs.ErrClosed) {
+		t.FailNow()
+	}
+	if info, _ := memory.Stat("/file"); info.Size() != 11 || info.Mode() != 0600 || info.IsDir() || info.Sys() != nil || info.ModTime().IsZero() {
+		t.FailNow()
+	}
+
+	file, _ = memory.OpenFile("/file", os.O_WRONLY, 0)
+	io.WriteString(file, "HELLO")
+	if _, err = file.Read(make([]byte, 1)); err == nil {
+		t.FailNow()
+	}
+	file.Close()
+	file, _ = memory.OpenFile("/file", os.O_APPEND|os.O_WRONLY, 0)
+	io.WriteString(file, "!")
+	file.Close()
+	file, _ = memory.OpenFile("/file", os.O_RDONLY, 0)
+	if content, _ := io.ReadAll(file); string(content) != "HELLO world!" {
+		t.Fatal(string(content))
+	}
+	if _, err = file.Write([]byte("x")); err == nil {
+		t.FailNow()
+	}
+	file.Close()
+
+	if _, err = memory.OpenFile("/file", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644); !errors.Is(err, fs.ErrExist) {
+		t.FailNow()
+	}
+	file, _ = memory.OpenFile("/file", os.O_TRUNC|os.O_WRONLY, 0)
+	file.Close()
+	if info, _ := memory.Stat("/file"); info.Size() != 0 {
+		t.FailNow()
+	}
+	if err = memory.MkdirAll("/file/sub", 0755); err == nil {
+		t.FailNow()
+	}
+	if _, err = memory.ReadDir("/file"); err == nil {
+		t.FailNow()
+	}
+	if _, err = memory.ReadDir("/missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.FailNow()
+	}
+}
+
+func TestMemoryFileSystemDirectories(t *testing.T) {
+	var memory = sx.NewMemoryFileSystem()
+	sx.ThrowIfError(memory.MkdirAll("/a/b", 0755))
+	sx.ThrowIfError(sx.NewErrorList(memory.MkdirAll("/", 0755), memory.MkdirAll("/a", 0755)).Err())
+	var file, _ = memory.OpenFile("/a/b/file", os.O_CREATE|os.O_WRONLY, 0644)
+	file.Close()
+
+	if _, err := memory.OpenFile("/a", os.O_WRONLY, 0); err == nil {
+		t.FailNow()
+	}
+	var dir, err = memory.OpenFile("/a", os.O_RDONLY, 0)
+	if err != nil || dir.Sync() != nil {
+		t.FailNow()
+	}
+	if _, err = dir.Read(make([]byte, 1)); err == nil {
+		t.FailNow()
+	}
+	if memory.Remove("/a") == nil || memory.Remove("/") == nil || !errors.Is(memory.Remove("/x"), fs.ErrNotExist) {
+		t.FailNow()
+	}
+
+	sx.ThrowIfError(memory.Rename("/a", "/c"))
+	if names := memoryReadDir(memory, "/c/b"); !reflect.DeepEqual(names, []string{"file"}) {
+		t.Fatal(names)
+	}
+	if _, err = memory.Stat("/a/b/file"); !errors.Is(err, fs.ErrNotExist) {
+		t.FailNow()
+	}
+	sx.ThrowIfError(memory.MkdirAll("/empty", 0755))
+	var renameErrors = []error{
+		memory.Rename("/x", "/y"),
+		memory.Rename("/c", "/c/b/d"),
+		memory.Rename("/c/b/file", "/missing/file"),
+		memory.Rename("/c/b/file", "/empty"),
+		memory.Rename("/empty", "/c/b/file"),
+		memory.Rename("/empty", "/c"),
+		memory.Rename("/", "/x"),
+	}
+	for i, err := range renameErrors {
+		if err == nil {
+			t.Fatal(i)
+		}
+	}
+	sx.ThrowIfError(sx.NewErrorList(memory.Rename("/c", "/c"), memory.Rename("/c", "/empty")).Err())
+	if names := memoryReadDir(memory, "/"); !reflect.DeepEqual(names, []string{"empty"}) {
+		t.Fatal(names)
+	}
+	sx.ThrowIfError(memory.Remove("/empty/b/file"))
+	sx.ThrowIfError(memory.Remove("/empty/b"))
+}
+
+func TestMemoryFileSystemCreateTemp(t *testing.T) {
+	var memory = sx.NewMemoryFileSystem()
+	var first, err = memory.CreateTemp("/", "x*.tmp")
+	sx.ThrowIfError(err)
+	second, err := memory.CreateTemp("/", "x*.tmp")
+	sx.ThrowIfError(err)
+	if first.Name() == second.Name() || !strings.HasPrefix(first.Name(), "/x") || !strings.HasSuffix(first.Name(), ".tmp") {
+		t.Fatal(first.Name(), second.Name())
+	}
+	if info, _ := memory.Stat(first.Name()); info.Mode() != 0600 {
+		t.FailNow()
+	}
+	if third, _ := memory.CreateTemp("/", "y"); !strings.HasPrefix(third.Name(), "/y") {
+		t.FailNow()
+	}
+	if _, err = memory.CreateTemp("/missing", "x"); !errors.Is(err, fs.ErrNotExist) {
+		t.FailNow()
+	}
+	if _, err = memory.CreateTemp("", "x"); err == nil {
+		t.FailNow() // the temp directory does not exist in memory
+	}
+}
+
+func TestMemoryFileSystemCalls(t *testing.T) {
+	var memory = sx.NewMemoryFileSystem()
+	var injected = errors.New("injected")
+	memory.FailNth(sx.FileOperationStat, 3, injected)
+	for i := 1; i <= 4; i++ {
+		var _, err = memory.Stat("/")
+		if (i == 3) != errors.Is(err, injected) {
+			t.Fatal(i)
+		}
+	}
+	if memory.Calls(sx.FileOperationStat) != 4 || memory.Calls(sx.FileOperationOpen) != 0 {
+		t.FailNow()
+	}
+	memory.FailNth(sx.FileOperationClose, 1, injected)
+	var file, _ = memory.OpenFile("/file", os.O_CREATE|os.O_WRONLY, 0644)
+	if !errors.Is(file.Close(), injected) || !errors.Is(file.Close(), fs.ErrClosed) {
+		t.FailNow()
+	}
+}
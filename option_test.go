@@ -2,6 +2,7 @@
 package sx_test
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -71,3 +72,61 @@ func TestFailingInvalidOptional(t *testing.T) {
 	opt.Value() //this must throw
 	t.FailNow()
 }
+
+func TestOptionalCombinators(t *testing.T) {
+	var empty = sx.NewOptional[int]()
+	var isEven = func(i int) bool { return i%2 == 0 }
+	if sx.NewOptionalFrom(2).Filter(isEven).Value() != 2 || sx.NewOptionalFrom(3).Filter(isEven).Ok() || empty.Filter(isEven).Ok() {
+		t.FailNow()
+	}
+	if sx.OptionalMap(sx.NewOptionalFrom(42), strconv.Itoa).Value() != "42" || sx.OptionalMap(empty, strconv.Itoa).Ok() {
+		t.FailNow()
+	}
+	var half = func(i int) sx.Optional[int] {
+		return sx.NewOptionalFrom(i / 2).Filter(func(int) bool { return isEven(i) })
+	}
+	if sx.OptionalFlatMap(sx.NewOptionalFrom(4), half).Value() != 2 || sx.OptionalFlatMap(sx.NewOptionalFrom(3), half).Ok() || sx.OptionalFlatMap(empty, half).Ok() {
+		t.FailNow()
+	}
+	var fallback = func() sx.Optional[int] { return sx.NewOptionalFrom(7) }
+	if empty.OrElse(fallback).Value() != 7 || sx.NewOptionalFrom(1).OrElse(fallback).Value() != 1 {
+		t.FailNow()
+	}
+}
+
+func TestOptionalConversions(t *testing.T) {
+	if sx.NewOptionalFrom(1).ToResult("missing").Value() != 1 {
+		t.FailNow()
+	}
+	if r := sx.NewOptional[int]().ToResult("missing ", "value"); r.Error() != "missing value" {
+		t.FailNow()
+	}
+	if r := sx.NewOptional[int]().ToResult(); r.Ok() {
+		t.FailNow()
+	}
+
+	var i = 42
+	var opt = sx.NewOptionalFromPointer(&i)
+	if opt.Value() != 42 || sx.NewOptionalFromPointer[int](nil).Ok() {
+		t.FailNow()
+	}
+	// the pointer points to a copy
+	var ptr = opt.ToPointer()
+	*ptr = 1
+	if opt.Value() != 42 || i != 42 || sx.NewOptional[int]().ToPointer() != nil {
+		t.FailNow()
+	}
+}
+
+func TestOptionalEquals(t *testing.T) {
+	if !sx.OptionalEquals(sx.NewOptional[int](), sx.NewOptional[int]()) || !sx.OptionalEquals(sx.NewOptionalFrom(1), sx.NewOptionalFrom(1)) {
+		t.FailNow()
+	}
+	if sx.OptionalEquals(sx.NewOptionalFrom(1), sx.NewOptionalFrom(2)) || sx.OptionalEquals(sx.NewOptionalFrom(0), sx.NewOptional[int]()) {
+		t.FailNow()
+	}
+	var sameLength = func(a, b []int) bool { return len(a) == len(b) }
+	if !sx.OptionalEqualsFunc(sx.NewOptionalFrom([]int{1}), sx.NewOptionalFrom([]int{2}), sameLength) {
+		t.FailNow()
+	}
+}
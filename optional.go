@@ -23,6 +23,14 @@ func NewOptionalFrom[V any](value V) Optional[V] {
 	return opt
 }
 
+// Creates an optional from a pointer, a nil pointer results in an empty optional
+func NewOptionalFromPointer[V any](value *V) Optional[V] {
+	if value == nil {
+		return NewOptional[V]()
+	}
+	return NewOptionalFrom(*value)
+}
+
 type Optional[T any] struct {
 	data  T
 	valid bool
@@ -67,3 +75,68 @@ func (opt Optional[T]) NewIterator() Iterator[int, T] {
 	}
 	return NewArray[T]().NewIterator()
 }
+
+// Keeps the value only if the condition is true for it
+func (opt Optional[T]) Filter(condition func(value T) bool) Optional[T] {
+	if opt.Ok() && condition(opt.data) {
+		return opt
+	}
+	return NewOptional[T]()
+}
+
+// Calls the fallback if the optional is empty, valid optionals are passed on unchanged
+func (opt Optional[T]) OrElse(fallback func() Optional[T]) Optional[T] {
+	if opt.Ok() {
+		return opt
+	}
+	return fallback()
+}
+
+// Converts to a result, an empty optional becomes an error with the given message
+func (opt Optional[T]) ToResult(errorMessageParts ...string) Result[T] {
+	if opt.Ok() {
+		return NewResultFrom(opt.data)
+	}
+	if len(errorMessageParts) == 0 {
+		return NewResultError[T]("Fatal error: accessing empty optional value")
+	}
+	return NewResultError[T](errorMessageParts...)
+}
+
+// Returns a pointer to a copy of the value, or nil if the optional is empty
+func (opt Optional[T]) ToPointer() *T {
+	if opt.IsEmpty() {
+		return nil
+	}
+	var value = opt.data
+	return &value
+}
+
+// Converts the value of a valid optional, empty optionals stay empty
+func OptionalMap[T any, TO any](opt Optional[T], mapper func(value T) TO) Optional[TO] {
+	if opt.IsEmpty() {
+		return NewOptional[TO]()
+	}
+	return NewOptionalFrom(mapper(opt.data))
+}
+
+// Calls the next step with the value of a valid optional, empty optionals stay empty
+func OptionalFlatMap[T any, TO any](opt Optional[T], next func(value T) Optional[TO]) Optional[TO] {
+	if opt.IsEmpty() {
+		return NewOptional[TO]()
+	}
+	return next(opt.data)
+}
+
+// Checks if both optionals are empty, or both hold equal values
+func OptionalEquals[T comparable](a Optional[T], b Optional[T]) bool {
+	return OptionalEqualsFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// Checks if both optionals are empty, or both hold values that are equal according to 'equal'
+func OptionalEqualsFunc[T any](a Optional[T], b Optional[T], equal func(a, b T) bool) bool {
+	if a.Ok() != b.Ok() {
+		return false
+	}
+	return a.IsEmpty() || equal(a.data, b.data)
+}
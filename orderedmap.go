@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import "iter"
+
+var _ Container = &orderedMapImpl[string, int]{}
+var _ Map[string, int] = NewOrderedMap[string, int]()
+var _ Map[string, int] = &orderedMapImpl[string, int]{}
+var _ Iterable[string, int] = &orderedMapImpl[string, int]{}
+var _ Iterator[string, int] = (&orderedMapImpl[string, int]{}).NewIterator()
+
+// Creates a map that remembers the insertion order of its keys
+//
+// Iteration and json encoding always follow the insertion order.
+// Putting an existing key again only updates its value, the position stays the same.
+func NewOrderedMap[K comparable, V any]() Map[K, V] {
+	var m orderedMapImpl[K, V]
+	m.Map = make(map[K]*orderedMapEntry[K, V])
+	return &m
+}
+
+// Creates an ordered map from key/value pairs, the order of the pairs is kept
+func NewOrderedMapFrom[K comparable, V any](pairs ...Pair[K, V]) Map[K, V] {
+	var m = NewOrderedMap[K, V]()
+	for _, pair := range pairs {
+		m.Put(pair.Key, pair.Value)
+	}
+	return m
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	prev    *orderedMapEntry[K, V]
+	next    *orderedMapEntry[K, V]
+	dropped bool // dropped entries keep their 'next' link, so running iterators can continue
+}
+
+type orderedMapImpl[K comparable, V any] struct {
+	Map   map[K]*orderedMapEntry[K, V]
+	first *orderedMapEntry[K, V]
+	last  *orderedMapEntry[K, V]
+}
+
+func (m *orderedMapImpl[K, V]) Length() int {
+	return len(m.Map)
+}
+
+func (m *orderedMapImpl[K, V]) IsEmpty() bool {
+	return m.Length() == 0
+}
+
+func (m *orderedMapImpl[K, V]) Has(key K) bool {
+	var _, ok = m.Map[key]
+	return ok
+}
+
+func (m *orderedMapImpl[K, V]) Get(key K) Result[V] {
+	var entry, ok = m.Map[key]
+	if !ok {
+		return NewResultError[V]("Fatal error: Key does not exist")
+	}
+	return NewResultFrom(entry.value)
+}
+
+func (m *orderedMapImpl[K, V]) Put(key K, value V) bool {
+	if m.Map == nil {
+		m.Map = make(map[K]*orderedMapEntry[K, V])
+	}
+	if entry, ok := m.Map[key]; ok {
+		entry.value = value
+		return true
+	}
+	var entry = &orderedMapEntry[K, V]{key: key, value: value, prev: m.last}
+	if m.last != nil {
+		m.last.next = entry
+	} else {
+		m.first = entry
+	}
+	m.last = entry
+	m.Map[key] = entry
+	return true
+}
+
+func (m *orderedMapImpl[K, V]) Drop(key K) bool {
+	var entry, ok = m.Map[key]
+	if !ok {
+		return false
+	}
+	delete(m.Map, key)
+	m.unlink(entry)
+	entry.dropped = true
+	return true
+}
+
+// Moves an entry to the end of the order, e.g. to mark it as most recently used
+func (m *orderedMapImpl[K, V]) moveToBack(entry *orderedMapEntry[K, V]) {
+	if entry == m.last {
+		return
+	}
+	m.unlink(entry)
+	entry.prev = m.last
+	entry.next = nil
+	m.last.next = entry
+	m.last = entry
+}
+
+func (m *orderedMapImpl[K, V]) unlink(entry *orderedMapEntry[K, V]) {
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		m.first = entry.next
+	}
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		m.last = entry.prev
+	}
+}
+
+func (m *orderedMapImpl[K, V]) NewIterator() Iterator[K, V] {
+	return &orderedMapIterator[K, V]{entry: m.first}
+}
+
+func (m *orderedMapImpl[K, V]) All() iter.Seq2[K, V] {
+	return Seq2[K, V](m)
+}
+
+type orderedMapIterator[K comparable, V any] struct {
+	entry *orderedMapEntry[K, V]
+}
+
+func (it *orderedMapIterator[K, V]) Ok() bool {
+	it.skipDropped()
+	return it.entry != nil
+}
+
+func (it *orderedMapIterator[K, V]) Key() K {
+	it.skipDropped()
+	return it.entry.key
+}
+
+func (it *orderedMapIterator[K, V]) Value() V {
+	it.skipDropped()
+	return it.entry.value
+}
+
+func (it *orderedMapIterator[K, V]) Next() {
+	it.skipDropped()
+	if it.entry != nil {
+		it.entry = it.entry.next
+	}
+}
+
+// Entries may be dropped while iterating, they are skipped
+func (it *orderedMapIterator[K, V]) skipDropped() {
+	for it.entry != nil && it.entry.dropped {
+		it.entry = it.entry.next
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func orderedKeys[K comparable, V any](m sx.Map[K, V]) []K {
+	var keys = []K{}
+	for it := m.NewIterator(); it.Ok(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+func TestOrderedMap(t *testing.T) {
+	var m = sx.NewOrderedMap[string, int]()
+	if !m.IsEmpty() || m.Length() != 0 || m.NewIterator().Ok() {
+		t.FailNow()
+	}
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	if m.IsEmpty() || m.Length() != 3 || !m.Has("a") || m.Has("d") {
+		t.FailNow()
+	}
+	if m.Get("b").Value() != 2 || m.Get("d").Ok() {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(orderedKeys(m), []string{"c", "a", "b"}) {
+		t.FailNow()
+	}
+
+	// updating keeps the position
+	m.Put("c", 33)
+	if m.Get("c").Value() != 33 || !reflect.DeepEqual(orderedKeys(m), []string{"c", "a", "b"}) {
+		t.FailNow()
+	}
+
+	// dropping and putting again moves the key to the end
+	if !m.Drop("c") || m.Drop("c") || m.Has("c") {
+		t.FailNow()
+	}
+	m.Put("c", 3)
+	if !reflect.DeepEqual(orderedKeys(m), []string{"a", "b", "c"}) {
+		t.FailNow()
+	}
+	m.Drop("c")
+	m.Drop("a")
+	if !reflect.DeepEqual(orderedKeys(m), []string{"b"}) {
+		t.FailNow()
+	}
+	m.Drop("b")
+	if !m.IsEmpty() || len(orderedKeys(m)) != 0 {
+		t.FailNow()
+	}
+	m.Put("x", 24)
+	if !reflect.DeepEqual(orderedKeys(m), []string{"x"}) {
+		t.FailNow()
+	}
+}
+
+func TestOrderedMapFrom(t *testing.T) {
+	var m = sx.NewOrderedMapFrom(sx.NewPair(3, "c"), sx.NewPair(1, "a"), sx.NewPair(2, "b"), sx.NewPair(3, "C"))
+	if m.Length() != 3 || m.Get(3).Value() != "C" {
+		t.FailNow()
+	}
+	var values = []string{}
+	for it := m.NewIterator(); it.Ok(); it.Next() {
+		values = append(values, it.Value())
+	}
+	if !reflect.DeepEqual(values, []string{"C", "a", "b"}) {
+		t.FailNow()
+	}
+}
+
+func TestOrderedMapIterationWithDeletion(t *testing.T) {
+	var m = sx.NewOrderedMapFrom(sx.NewPair("a", 1), sx.NewPair("b", 2), sx.NewPair("c", 3), sx.NewPair("d", 4), sx.NewPair("e", 5))
+	var foundKeys = []string{}
+	for it := m.NewIterator(); it.Ok(); it.Next() {
+		foundKeys = append(foundKeys, it.Key())
+		switch it.Key() {
+		case "b":
+			m.Drop("c")
+		case "d":
+			// drop the current entry and its successor
+			m.Drop("d")
+			m.Drop("e")
+		}
+	}
+	if !reflect.DeepEqual(foundKeys, []string{"a", "b", "d"}) || !reflect.DeepEqual(orderedKeys(m), []string{"a", "b"}) {
+		t.FailNow()
+	}
+
+	// the current entry is dropped, but the iterator still finds the next one
+	var it = m.NewIterator()
+	m.Drop("a")
+	if !it.Ok() || it.Key() != "b" || it.Value() != 2 {
+		t.FailNow()
+	}
+	it.Next()
+	it.Next()
+	if it.Ok() {
+		t.FailNow()
+	}
+}
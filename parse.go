@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parses a signed integer, the bit size is taken from T
+//
+// Base 0 detects the base from the prefix ("0x", "0o" or "0", "0b") and allows underscores, e.g. "0xFF" or "1_000"
+func ParseInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](s string, base int) Result[T] {
+	var value, err = strconv.ParseInt(s, base, reflect.TypeFor[T]().Bits())
+	if err != nil {
+		return parseError[T](s, err)
+	}
+	return NewResultFrom(T(value))
+}
+
+// Parses an unsigned integer, the bit size is taken from T
+//
+// Base 0 detects the base from the prefix ("0x", "0o" or "0", "0b") and allows underscores, e.g. "0xFF" or "1_000"
+func ParseUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](s string, base int) Result[T] {
+	var value, err = strconv.ParseUint(s, base, reflect.TypeFor[T]().Bits())
+	if err != nil {
+		return parseError[T](s, err)
+	}
+	return NewResultFrom(T(value))
+}
+
+// Parses a floating point number, independent of the locale (always '.' as decimal separator)
+func ParseFloat[T ~float32 | ~float64](s string) Result[T] {
+	var value, err = strconv.ParseFloat(s, reflect.TypeFor[T]().Bits())
+	if err != nil {
+		return parseError[T](s, err)
+	}
+	return NewResultFrom(T(value))
+}
+
+// Parses a duration like "1h30m" or "250ms", see time.ParseDuration
+func ParseDuration(s string) Result[time.Duration] {
+	var value, err = time.ParseDuration(s)
+	if err != nil {
+		return parseError[time.Duration](s, err)
+	}
+	return NewResultFrom(value)
+}
+
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"m":   1e6,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"g":   1e9,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"t":   1e12,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"p":   1e15,
+	"pb":  1e15,
+	"pib": 1 << 50,
+	"e":   1e18,
+	"eb":  1e18,
+	"eib": 1 << 60,
+}
+
+// Parses a human readable number of bytes like "512", "10MiB", "1.5 GB" or "4k"
+//
+// Units are case-insensitive, "KB"/"MB"/... are powers of 1000 and "KiB"/"MiB"/... are powers of 1024
+func ParseByteSize(s string) Result[uint64] {
+	var trimmed = strings.TrimSpace(s)
+	var unitStart = strings.LastIndexFunc(trimmed, func(r rune) bool { return r == '.' || r >= '0' && r <= '9' }) + 1
+	var unit, ok = byteSizeUnits[strings.ToLower(strings.TrimSpace(trimmed[unitStart:]))]
+	if !ok {
+		return parseError[uint64](s, errors.New("unknown unit"))
+	}
+	var number, err = strconv.ParseFloat(trimmed[:unitStart], 64)
+	if err != nil {
+		return parseError[uint64](s, err)
+	}
+	var size = math.Round(number * unit)
+	if size < 0 || size >= math.MaxUint64 {
+		return parseError[uint64](s, strconv.ErrRange)
+	}
+	return NewResultFrom(uint64(size))
+}
+
+// Parses true/false, yes/no, on/off and 1/0, case-insensitive
+func String2Bool(s string) Result[bool] {
+	switch strings.ToLower(s) {
+	case "true", "yes", "on", "1":
+		return NewResultFrom(true)
+	case "false", "no", "off", "0":
+		return NewResultFrom(false)
+	}
+	return parseError[bool](s, errors.New("invalid syntax"))
+}
+
+// Parses a decimal int64
+func String2Int(s string) Result[int64] {
+	return ParseInt[int64](s, 10)
+}
+
+// Parses a float64
+func String2Float(s string) Result[float64] {
+	return ParseFloat[float64](s)
+}
+
+// The error contains the offending input, errors.Is finds the cause (e.g. strconv.ErrRange)
+func parseError[T any](s string, cause error) Result[T] {
+	var numErr *strconv.NumError
+	if errors.As(cause, &numErr) {
+		cause = numErr.Err
+	}
+	return NewResultFromError[T](WrapError(cause, StrCat("Fatal error: cannot convert string '", s, "' to ", ReflectTypeName[T]())))
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestParseInt(t *testing.T) {
+	if sx.ParseInt[int](" 42", 10).Ok() || sx.ParseInt[int]("42", 10).Value() != 42 || sx.ParseInt[int64]("-42", 10).Value() != -42 {
+		t.FailNow()
+	}
+	if sx.ParseInt[int]("0xff", 0).Value() != 255 || sx.ParseInt[int]("0b101", 0).Value() != 5 || sx.ParseInt[int]("0o17", 0).Value() != 15 {
+		t.FailNow()
+	}
+	if sx.ParseInt[int]("1_000_000", 0).Value() != 1000000 || sx.ParseInt[int]("1_000", 10).Ok() || sx.ParseInt[int]("ff", 16).Value() != 255 {
+		t.FailNow()
+	}
+	if sx.ParseInt[int8]("127", 10).Value() != 127 {
+		t.FailNow()
+	}
+	var r = sx.ParseInt[int8]("128", 10)
+	if r.Ok() || !errors.Is(r.Err(), strconv.ErrRange) || r.Error() != "Fatal error: cannot convert string '128' to int8: value out of range" {
+		t.FailNow()
+	}
+	type level int16
+	if r := sx.ParseInt[level]("x1", 10); !errors.Is(r.Err(), strconv.ErrSyntax) || r.Error() != "Fatal error: cannot convert string 'x1' to level: invalid syntax" {
+		t.FailNow()
+	}
+}
+
+func TestParseUint(t *testing.T) {
+	if sx.ParseUint[uint8]("255", 10).Value() != 255 || sx.ParseUint[uint8]("256", 10).Ok() || sx.ParseUint[uint]("-1", 10).Ok() {
+		t.FailNow()
+	}
+	if sx.ParseUint[uint64]("0xFFFF_FFFF_FFFF_FFFF", 0).Value() != 1<<64-1 || sx.ParseUint[uint16]("777", 8).Value() != 511 {
+		t.FailNow()
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	if sx.ParseFloat[float64]("3.1415").Value() != 3.1415 || sx.ParseFloat[float64]("1e3").Value() != 1000 || sx.ParseFloat[float64]("3,1415").Ok() {
+		t.FailNow()
+	}
+	if sx.ParseFloat[float32]("1e39").Ok() || sx.ParseFloat[float64]("1e39").Value() != 1e39 {
+		t.FailNow()
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	if sx.ParseDuration("1h30m").Value() != 90*time.Minute || sx.ParseDuration("250ms").Value() != 250*time.Millisecond {
+		t.FailNow()
+	}
+	if r := sx.ParseDuration("10 days"); r.Error() != `Fatal error: cannot convert string '10 days' to Duration: time: unknown unit " days" in duration "10 days"` {
+		t.FailNow()
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	var expected = map[string]uint64{
+		"512":    512,
+		"512B":   512,
+		"4k":     4000,
+		"10MiB":  10 << 20,
+		"10 mib": 10 << 20,
+		"1.5 GB": 1500000000,
+		"0.5KiB": 512,
+		"2TiB":   2 << 40,
+		"1EiB":   1 << 60,
+	}
+	for s, size := range expected {
+		if sx.ParseByteSize(s).Value() != size {
+			t.Fatal(s)
+		}
+	}
+	for _, s := range []string{"", "MiB", "10 XB", "-1KB", "1.2.3MB", "100EB"} {
+		if sx.ParseByteSize(s).Ok() {
+			t.Fatal(s)
+		}
+	}
+	if r := sx.ParseByteSize("10 XB"); r.Error() != "Fatal error: cannot convert string '10 XB' to uint64: unknown unit" {
+		t.FailNow()
+	}
+}
+
+func TestString2Bool(t *testing.T) {
+	for _, s := range []string{"true", "TRUE", "Yes", "on", "1"} {
+		if !sx.String2Bool(s).Value() {
+			t.Fatal(s)
+		}
+	}
+	for _, s := range []string{"false", "False", "no", "OFF", "0"} {
+		if sx.String2Bool(s).Value() {
+			t.Fatal(s)
+		}
+	}
+	if r := sx.String2Bool("truthy"); r.Error() != "Fatal error: cannot convert string 'truthy' to bool: invalid syntax" {
+		t.FailNow()
+	}
+}
+
+func TestString2IntAndFloatErrors(t *testing.T) {
+	if sx.String2Int("A38").Ok() || sx.String2Int("0x10").Ok() || sx.String2Float("3.14.15").Ok() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var _ io.Writer = &Printer{}
+var _ io.Writer = stdErrWriter{}
+
+// ANSI escape sequence for colored terminal output
+type Color string
+
+const (
+	ColorReset   Color = "\x1b[0m"
+	ColorBold    Color = "\x1b[1m"
+	ColorRed     Color = "\x1b[31m"
+	ColorGreen   Color = "\x1b[32m"
+	ColorYellow  Color = "\x1b[33m"
+	ColorBlue    Color = "\x1b[34m"
+	ColorMagenta Color = "\x1b[35m"
+	ColorCyan    Color = "\x1b[36m"
+	ColorGray    Color = "\x1b[90m"
+)
+
+// Prints to any io.Writer, all methods are goroutine-safe
+type Printer struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	color  bool
+}
+
+// Creates a printer, colors are enabled if the writer is a terminal and the NO_COLOR environment variable is not set
+func NewPrinter(writer io.Writer) *Printer {
+	return &Printer{writer: writer, color: isColorTerminal(writer)}
+}
+
+func isColorTerminal(writer io.Writer) bool {
+	var file, ok = writer.(*os.File)
+	if !ok || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	var info, err = file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Enables or disables colors, regardless of the writer
+func (p *Printer) SetColor(enabled bool) *Printer {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.color = enabled
+	return p
+}
+
+func (p *Printer) ColorEnabled() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.color
+}
+
+// The underlying writer
+func (p *Printer) Writer() io.Writer {
+	return p.writer
+}
+
+// Writes the bytes unchanged
+func (p *Printer) Write(bytes []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.writer.Write(bytes)
+}
+
+func (p *Printer) Print(values ...string) {
+	p.Write([]byte(StrCat(values...)))
+}
+
+func (p *Printer) PrintLn(values ...string) {
+	p.Write([]byte(StrCat(StrCat(values...), "\n")))
+}
+
+func (p *Printer) PrintAny(values ...any) {
+	p.Write([]byte(Str(values...)))
+}
+
+func (p *Printer) PrintAnyLn(values ...any) {
+	p.Write([]byte(StrCat(Str(values...), "\n")))
+}
+
+// Wraps the text in the color, returns it unchanged if colors are disabled
+func (p *Printer) Colorize(color Color, text string) string {
+	if !p.ColorEnabled() {
+		return text
+	}
+	return StrCat(string(color), text, string(ColorReset))
+}
+
+func (p *Printer) PrintColor(color Color, values ...string) {
+	p.Print(p.Colorize(color, StrCat(values...)))
+}
+
+func (p *Printer) PrintColorLn(color Color, values ...string) {
+	p.PrintLn(p.Colorize(color, StrCat(values...)))
+}
+
+var stdOut, stdErr atomic.Pointer[Printer]
+
+func init() {
+	stdOut.Store(NewPrinter(os.Stdout))
+	stdErr.Store(NewPrinter(os.Stderr))
+}
+
+// The printer used by Print, PrintLn, PrintAny and PrintAnyLn
+func StdOut() *Printer {
+	return stdOut.Load()
+}
+
+// The printer used by PrintStdErr, PrintStdErrLn, PrintAnyStdErr, PrintAnyStdErrLn, Debug and the stderr log sink
+func StdErr() *Printer {
+	return stdErr.Load()
+}
+
+// Redirects the default output, e.g. to capture it in tests
+//
+// Call the returned function to restore the previous writer
+func SetStdOut(writer io.Writer) (restore func()) {
+	var previous = stdOut.Swap(NewPrinter(writer))
+	return func() { stdOut.Store(previous) }
+}
+
+// Redirects the default error output, e.g. to a log file
+//
+// Call the returned function to restore the previous writer
+func SetStdErr(writer io.Writer) (restore func()) {
+	var previous = stdErr.Swap(NewPrinter(writer))
+	return func() { stdErr.Store(previous) }
+}
+
+// Writes to the current StdErr printer, so redirections also apply to writers created earlier
+type stdErrWriter struct{}
+
+func (stdErrWriter) Write(bytes []byte) (int, error) {
+	return StdErr().Write(bytes)
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestPrinter(t *testing.T) {
+	var sb strings.Builder
+	var p = sx.NewPrinter(&sb)
+	p.Print("a", "b")
+	p.PrintLn("c")
+	p.PrintAny(1, "x", 2.5)
+	p.PrintAnyLn(errors.New("e"))
+	if sb.String() != "abc\n1x2.5e\n" || p.Writer() != &sb || p.ColorEnabled() {
+		t.FailNow()
+	}
+
+	sb.Reset()
+	p.PrintColorLn(sx.ColorRed, "no color")
+	p.SetColor(true).PrintColor(sx.ColorGreen, "ok")
+	if sb.String() != "no color\n\x1b[32mok\x1b[0m" {
+		t.FailNow()
+	}
+}
+
+func TestPrinterColorDetection(t *testing.T) {
+	if sx.RunningOnWindows {
+		t.Skip()
+	}
+	var devNull, err = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.FailNow()
+	}
+	defer devNull.Close()
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+	if !sx.NewPrinter(devNull).ColorEnabled() {
+		t.FailNow()
+	}
+	t.Setenv("NO_COLOR", "1")
+	if sx.NewPrinter(devNull).ColorEnabled() {
+		t.FailNow()
+	}
+	t.Setenv("NO_COLOR", "")
+	var file, _ = os.CreateTemp("", "sx_printer")
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if sx.NewPrinter(file).ColorEnabled() {
+		t.FailNow()
+	}
+}
+
+func TestSetStdOutAndStdErr(t *testing.T) {
+	var out, errOut strings.Builder
+	var restoreOut = sx.SetStdOut(&out)
+	var restoreErr = sx.SetStdErr(&errOut)
+	var logger = sx.NewLogger()
+	sx.DebugPushOn()
+	defer sx.DebugPop()
+
+	sx.Print("a")
+	sx.PrintLn("b")
+	sx.PrintAny(1)
+	sx.PrintAnyLn(2)
+	sx.PrintStdErr("c")
+	sx.PrintStdErrLn("d")
+	sx.PrintAnyStdErr(3)
+	sx.PrintAnyStdErrLn(4)
+	sx.Debug("debug")
+	sx.DebugAnyCtx(context.Background(), "ctx")
+	logger.Warn("logged")
+	restoreOut()
+	restoreErr()
+	sx.PrintStdErr("")
+
+	var errLines = strings.Split(errOut.String(), "\n")
+	if out.String() != "ab\n12\n" || !strings.HasPrefix(errOut.String(), "cd\n34\n") || len(errLines) != 6 {
+		t.Fatal(errOut.String())
+	}
+	if !strings.Contains(errLines[2], " DEBUG ") || !strings.HasSuffix(errLines[2], ": debug") || !strings.HasSuffix(errLines[3], ": ctx") || !strings.HasSuffix(errLines[4], ": logged") {
+		t.FailNow()
+	}
+	if sx.StdOut().Writer() != os.Stdout || sx.StdErr().Writer() != os.Stderr {
+		t.FailNow()
+	}
+}
+
+func TestPrinterConcurrency(t *testing.T) {
+	var sb strings.Builder
+	var restore = sx.SetStdOut(&sb)
+	defer restore()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sx.PrintLn("line")
+			}
+		}()
+	}
+	wg.Wait()
+	if strings.Count(sb.String(), "line\n") != 800 {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import "container/heap"
+
+var _ Container = &priorityQueueImpl[int]{}
+var _ Stack[int] = NewPriorityQueue[int](func(a, b int) bool { return a < b })
+var _ PriorityQueue[int] = &priorityQueueImpl[int]{}
+var _ Iterator[int, int] = &priorityQueueDrainIterator[int]{}
+
+// Creates a priority queue, backed by a binary heap
+//
+// The smallest value according to 'less' is returned first by Peek and Pop.
+// Use 'a > b' as 'less' to get the largest value first.
+// Push and Pop are O(log n), Peek is O(1)
+func NewPriorityQueue[V any](less func(a, b V) bool) PriorityQueue[V] {
+	return &priorityQueueImpl[V]{heap: priorityQueueHeap[V]{less: less}}
+}
+
+func NewPriorityQueueFrom[V any](less func(a, b V) bool, values ...V) PriorityQueue[V] {
+	var pq = &priorityQueueImpl[V]{heap: priorityQueueHeap[V]{less: less}}
+	for _, value := range values {
+		pq.heap.items = append(pq.heap.items, &priorityQueueItem[V]{value: value, index: len(pq.heap.items)})
+	}
+	heap.Init(&pq.heap)
+	return pq
+}
+
+// Refers to an item in a priority queue, as long as the item is queued
+type PriorityQueueHandle[V any] struct {
+	item *priorityQueueItem[V]
+}
+
+// Checks if the item is still queued
+func (handle PriorityQueueHandle[V]) Ok() bool {
+	return handle.item != nil && handle.item.index >= 0
+}
+
+// Returns the value of the item, also works after the item was removed from the queue
+func (handle PriorityQueueHandle[V]) Value() V {
+	if handle.item == nil {
+		Throw("Fatal error: accessing empty priority queue handle")
+	}
+	return handle.item.value
+}
+
+type priorityQueueItem[V any] struct {
+	value V
+	index int // position in the heap, -1 after removal
+}
+
+type priorityQueueImpl[V any] struct {
+	heap priorityQueueHeap[V]
+}
+
+func (pq *priorityQueueImpl[V]) Length() int   { return pq.heap.Len() }
+func (pq *priorityQueueImpl[V]) IsEmpty() bool { return pq.Length() == 0 }
+
+func (pq *priorityQueueImpl[V]) Push(values ...V) {
+	for _, value := range values {
+		pq.PushHandle(value)
+	}
+}
+
+func (pq *priorityQueueImpl[V]) PushArray(arr Array[V]) {
+	pq.Push(arr.SubSlice()...)
+}
+
+func (pq *priorityQueueImpl[V]) PushHandle(value V) PriorityQueueHandle[V] {
+	var item = &priorityQueueItem[V]{value: value}
+	heap.Push(&pq.heap, item)
+	return PriorityQueueHandle[V]{item: item}
+}
+
+func (pq *priorityQueueImpl[V]) Peek() Result[V] {
+	if pq.IsEmpty() {
+		return NewResultError[V]("Fatal error: trying to peek empty priority queue")
+	}
+	return NewResultFrom(pq.heap.items[0].value)
+}
+
+func (pq *priorityQueueImpl[V]) Pop() Result[V] {
+	if pq.IsEmpty() {
+		return NewResultError[V]("Fatal error: trying to pop empty priority queue")
+	}
+	var item = heap.Pop(&pq.heap).(*priorityQueueItem[V])
+	return NewResultFrom(item.value)
+}
+
+func (pq *priorityQueueImpl[V]) Update(handle PriorityQueueHandle[V], value V) bool {
+	if !pq.owns(handle) {
+		return false
+	}
+	handle.item.value = value
+	heap.Fix(&pq.heap, handle.item.index)
+	return true
+}
+
+func (pq *priorityQueueImpl[V]) Fix(handle PriorityQueueHandle[V]) bool {
+	if !pq.owns(handle) {
+		return false
+	}
+	heap.Fix(&pq.heap, handle.item.index)
+	return true
+}
+
+func (pq *priorityQueueImpl[V]) Remove(handle PriorityQueueHandle[V]) Result[V] {
+	if !pq.owns(handle) {
+		return NewResultError[V]("Fatal error: item is not queued")
+	}
+	var item = heap.Remove(&pq.heap, handle.item.index).(*priorityQueueItem[V])
+	return NewResultFrom(item.value)
+}
+
+func (pq *priorityQueueImpl[V]) Drain() Iterator[int, V] {
+	return &priorityQueueDrainIterator[V]{queue: pq}
+}
+
+func (pq *priorityQueueImpl[V]) owns(handle PriorityQueueHandle[V]) bool {
+	return handle.Ok() && handle.item.index < pq.heap.Len() && pq.heap.items[handle.item.index] == handle.item
+}
+
+// Implements container/heap.Interface
+type priorityQueueHeap[V any] struct {
+	items []*priorityQueueItem[V]
+	less  func(a, b V) bool
+}
+
+func (h priorityQueueHeap[V]) Len() int           { return len(h.items) }
+func (h priorityQueueHeap[V]) Less(i, j int) bool { return h.less(h.items[i].value, h.items[j].value) }
+func (h priorityQueueHeap[V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *priorityQueueHeap[V]) Push(x any) {
+	var item = x.(*priorityQueueItem[V])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *priorityQueueHeap[V]) Pop() any {
+	var last = len(h.items) - 1
+	var item = h.items[last]
+	h.items[last] = nil
+	h.items = h.items[:last]
+	item.index = -1
+	return item
+}
+
+// Pops one value per step on the first Ok or Value call, so values pushed while draining are returned in order, too
+type priorityQueueDrainIterator[V any] struct {
+	queue   *priorityQueueImpl[V]
+	current Result[V]
+	popped  bool
+	index   int
+}
+
+func (it *priorityQueueDrainIterator[V]) pop() Result[V] {
+	if !it.popped {
+		it.current = it.queue.Pop()
+		it.popped = true
+	}
+	return it.current
+}
+
+func (it *priorityQueueDrainIterator[V]) Ok() bool { return it.pop().Ok() }
+func (it *priorityQueueDrainIterator[V]) Key() int { return it.index }
+func (it *priorityQueueDrainIterator[V]) Value() V { return it.pop().Value() }
+func (it *priorityQueueDrainIterator[V]) Next() {
+	if it.Ok() {
+		it.popped = false
+		it.index++
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	var pq = sx.NewPriorityQueue(intLess)
+	if !pq.IsEmpty() || pq.Length() != 0 || pq.Peek().Ok() || pq.Pop().Ok() {
+		t.FailNow()
+	}
+	pq.Push(5, 1, 4)
+	pq.PushArray(sx.NewArrayFrom(3, 2))
+	if pq.IsEmpty() || pq.Length() != 5 || pq.Peek().Value() != 1 {
+		t.FailNow()
+	}
+	var popped = []int{}
+	for !pq.IsEmpty() {
+		popped = append(popped, pq.Pop().Value())
+	}
+	if !reflect.DeepEqual(popped, []int{1, 2, 3, 4, 5}) {
+		t.FailNow()
+	}
+
+	// max heap
+	var maxQueue = sx.NewPriorityQueueFrom(func(a, b int) bool { return a > b }, 3, 9, 1)
+	if maxQueue.Length() != 3 || maxQueue.Pop().Value() != 9 || maxQueue.Pop().Value() != 3 || maxQueue.Pop().Value() != 1 {
+		t.FailNow()
+	}
+}
+
+type job struct {
+	name     string
+	priority int
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	var pq = sx.NewPriorityQueue(func(a, b *job) bool { return a.priority < b.priority })
+	var a = pq.PushHandle(&job{"a", 10})
+	var b = pq.PushHandle(&job{"b", 20})
+	var c = pq.PushHandle(&job{"c", 30})
+	if !a.Ok() || a.Value().name != "a" || pq.Peek().Value().name != "a" {
+		t.FailNow()
+	}
+
+	// replace the value
+	if !pq.Update(c, &job{"c", 5}) || pq.Peek().Value().name != "c" {
+		t.FailNow()
+	}
+
+	// modify the value in place
+	b.Value().priority = 1
+	if !pq.Fix(b) || pq.Peek().Value().name != "b" {
+		t.FailNow()
+	}
+
+	// remove an item in the middle
+	if pq.Remove(c).Value().name != "c" || c.Ok() || pq.Length() != 2 {
+		t.FailNow()
+	}
+	if pq.Remove(c).Ok() || pq.Update(c, &job{"c", 0}) || pq.Fix(c) {
+		t.FailNow()
+	}
+	if c.Value().name != "c" {
+		t.FailNow()
+	}
+
+	// popped items are no longer queued
+	if pq.Pop().Value().name != "b" || b.Ok() || pq.Fix(b) {
+		t.FailNow()
+	}
+
+	// handles of other queues are rejected
+	var other = sx.NewPriorityQueue(func(a, b *job) bool { return a.priority < b.priority })
+	var foreign = other.PushHandle(&job{"x", 0})
+	if pq.Fix(foreign) || pq.Remove(foreign).Ok() || pq.Length() != 1 || other.Length() != 1 {
+		t.FailNow()
+	}
+
+	var empty sx.PriorityQueueHandle[int]
+	if empty.Ok() || sx.NewPriorityQueue(intLess).Fix(empty) {
+		t.FailNow()
+	}
+	defer sx.Catch(func(err error) {})
+	empty.Value()
+	t.FailNow()
+}
+
+func TestPriorityQueueDrain(t *testing.T) {
+	var pq = sx.NewPriorityQueueFrom(intLess, 4, 2, 6)
+	var keys, values = []int{}, []int{}
+	for it := pq.Drain(); it.Ok(); it.Next() {
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
+		if it.Value() == 2 {
+			pq.Push(3) // pushed while draining, still returned in order
+		}
+	}
+	if !reflect.DeepEqual(keys, []int{0, 1, 2, 3}) || !reflect.DeepEqual(values, []int{2, 3, 4, 6}) || !pq.IsEmpty() {
+		t.FailNow()
+	}
+	var it = pq.Drain()
+	it.Next()
+	if it.Ok() {
+		t.FailNow()
+	}
+}
+
+func TestPriorityQueueDrainIsLazy(t *testing.T) {
+	var pq = sx.NewPriorityQueueFrom(intLess, 4, 2, 6)
+	var it = pq.Drain()
+	if pq.Length() != 3 {
+		t.FailNow()
+	}
+	if !it.Ok() || it.Value() != 2 || it.Value() != 2 || pq.Length() != 2 {
+		t.FailNow()
+	}
+	it.Next()
+	if pq.Length() != 2 || it.Key() != 1 {
+		t.FailNow()
+	}
+	pq.Push(1) // pushed before the next value is accessed
+	if it.Value() != 1 || pq.Length() != 2 {
+		t.FailNow()
+	}
+}
+
+func TestPriorityQueueRandomized(t *testing.T) {
+	var random = rand.New(rand.NewSource(3))
+	var pq = sx.NewPriorityQueue(intLess)
+	var handles = []sx.PriorityQueueHandle[int]{}
+	for i := 0; i < 500; i++ {
+		handles = append(handles, pq.PushHandle(random.Intn(1000)))
+	}
+	for i := 0; i < 100; i++ {
+		pq.Update(handles[random.Intn(len(handles))], random.Intn(1000))
+		pq.Remove(handles[random.Intn(len(handles))])
+	}
+	var reference = []int{}
+	for _, handle := range handles {
+		if handle.Ok() {
+			reference = append(reference, handle.Value())
+		}
+	}
+	sort.Ints(reference)
+	if !reflect.DeepEqual(sx.CollectArray(pq.Drain()).SubSlice(), reference) {
+		t.FailNow()
+	}
+}
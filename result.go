@@ -18,6 +18,14 @@ func NewResultFromError[V any](err error) Result[V] {
 	return opt
 }
 
+// Wraps go's usual (value, error) return values, e.g. NewResultFromTuple(strconv.Atoi("42"))
+func NewResultFromTuple[V any](value V, err error) Result[V] {
+	if err != nil {
+		return NewResultFromError[V](err)
+	}
+	return NewResultFrom(value)
+}
+
 type Result[T any] struct {
 	data T
 	err  error
@@ -29,7 +37,7 @@ func (r Result[T]) Ok() bool {
 
 func (r Result[T]) Value() T {
 	if !r.Ok() {
-		Throw(r.err.Error())
+		ThrowIfError(r.err)
 	}
 	return r.data
 }
@@ -65,3 +73,75 @@ func (r Result[T]) Error() string {
 	}
 	return r.err.Error()
 }
+
+// The error of a failed result, nil for valid results
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Converts back to go's usual (value, error) return values
+func (r Result[T]) Tuple() (T, error) {
+	return r.data, r.err
+}
+
+// Converts the value of a valid result, errors are passed on unchanged
+func ResultMap[T any, TO any](r Result[T], mapper func(value T) TO) Result[TO] {
+	if !r.Ok() {
+		return NewResultFromError[TO](r.err)
+	}
+	return NewResultFrom(mapper(r.data))
+}
+
+// Calls the next fallible step with the value of a valid result, errors are passed on unchanged
+func ResultAndThen[T any, TO any](r Result[T], next func(value T) Result[TO]) Result[TO] {
+	if !r.Ok() {
+		return NewResultFromError[TO](r.err)
+	}
+	return next(r.data)
+}
+
+// Calls the fallback with the error of a failed result, valid results are passed on unchanged
+func ResultOrElse[T any](r Result[T], fallback func(err error) Result[T]) Result[T] {
+	if r.Ok() {
+		return r
+	}
+	return fallback(r.err)
+}
+
+// Combines two valid results into a pair, otherwise returns the first error
+func ResultZip[T1 any, T2 any](first Result[T1], second Result[T2]) Result[Pair[T1, T2]] {
+	if !first.Ok() {
+		return NewResultFromError[Pair[T1, T2]](first.err)
+	}
+	if !second.Ok() {
+		return NewResultFromError[Pair[T1, T2]](second.err)
+	}
+	return NewResultFrom(NewPair(first.data, second.data))
+}
+
+// Collects the values of all results into a new sx.Array, stops at the first error and returns it
+func ResultCollect[T any](results Array[Result[T]]) Result[Array[T]] {
+	var values = NewArray[T](results.Length())
+	for it := results.NewIterator(); it.Ok(); it.Next() {
+		var r = it.Value()
+		if !r.Ok() {
+			return NewResultFromError[Array[T]](r.err)
+		}
+		values.Push(r.data)
+	}
+	return NewResultFrom(values)
+}
+
+// Splits the results into the values of the valid ones and the errors of the failed ones, both in order
+func ResultPartition[T any](results Array[Result[T]]) (values Array[T], errs Array[error]) {
+	values, errs = NewArray[T](), NewArray[error]()
+	for it := results.NewIterator(); it.Ok(); it.Next() {
+		var r = it.Value()
+		if r.Ok() {
+			values.Push(r.data)
+		} else {
+			errs.Push(r.err)
+		}
+	}
+	return values, errs
+}
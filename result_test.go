@@ -3,6 +3,7 @@ package sx_test
 
 import (
 	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/ZeroBsd/sx"
@@ -83,3 +84,88 @@ func TestValueisOptional(t *testing.T) {
 		}
 	}
 }
+
+func TestResultFromTuple(t *testing.T) {
+	if r := sx.NewResultFromTuple(strconv.Atoi("42")); r.Value() != 42 {
+		t.FailNow()
+	}
+	var r = sx.NewResultFromTuple(strconv.Atoi("x"))
+	if r.Ok() {
+		t.FailNow()
+	}
+	if value, err := r.Tuple(); value != 0 || err == nil {
+		t.FailNow()
+	}
+	if value, err := sx.NewResultFrom("a").Tuple(); value != "a" || err != nil {
+		t.FailNow()
+	}
+}
+
+func TestResultMapAndThen(t *testing.T) {
+	var parse = func(s string) sx.Result[int] { return sx.NewResultFromTuple(strconv.Atoi(s)) }
+	var half = func(i int) sx.Result[int] {
+		if i%2 != 0 {
+			return sx.NewResultError[int]("odd")
+		}
+		return sx.NewResultFrom(i / 2)
+	}
+	if r := sx.ResultAndThen(parse("42"), half); r.Value() != 21 {
+		t.FailNow()
+	}
+	if r := sx.ResultAndThen(parse("21"), half); r.Error() != "odd" {
+		t.FailNow()
+	}
+	if r := sx.ResultAndThen(sx.ResultAndThen(parse("x"), half), half); r.Ok() {
+		t.FailNow()
+	}
+	if r := sx.ResultMap(parse("42"), strconv.Itoa); r.Value() != "42" {
+		t.FailNow()
+	}
+	if r := sx.ResultMap(sx.NewResultError[int]("failed"), strconv.Itoa); r.Error() != "failed" {
+		t.FailNow()
+	}
+}
+
+func TestResultOrElse(t *testing.T) {
+	var fallback = func(err error) sx.Result[int] { return sx.NewResultFrom(len(err.Error())) }
+	if r := sx.ResultOrElse(sx.NewResultError[int]("abc"), fallback); r.Value() != 3 {
+		t.FailNow()
+	}
+	if r := sx.ResultOrElse(sx.NewResultFrom(42), fallback); r.Value() != 42 {
+		t.FailNow()
+	}
+}
+
+func TestResultZip(t *testing.T) {
+	if r := sx.ResultZip(sx.NewResultFrom(1), sx.NewResultFrom("a")); r.Value() != sx.NewPair(1, "a") {
+		t.FailNow()
+	}
+	if r := sx.ResultZip(sx.NewResultError[int]("first"), sx.NewResultError[string]("second")); r.Error() != "first" {
+		t.FailNow()
+	}
+	if r := sx.ResultZip(sx.NewResultFrom(1), sx.NewResultError[string]("second")); r.Error() != "second" {
+		t.FailNow()
+	}
+}
+
+func TestResultCollectAndPartition(t *testing.T) {
+	var valid = sx.NewArrayFrom(sx.NewResultFrom(1), sx.NewResultFrom(2))
+	if r := sx.ResultCollect(valid); r.Value().Length() != 2 || r.Value().Get(1).Value() != 2 {
+		t.FailNow()
+	}
+	if r := sx.ResultCollect(sx.NewArray[sx.Result[int]]()); !r.Ok() || !r.Value().IsEmpty() {
+		t.FailNow()
+	}
+
+	var mixed = sx.NewArrayFrom(sx.NewResultFrom(1), sx.NewResultError[int]("a"), sx.NewResultFrom(3), sx.NewResultError[int]("b"))
+	if r := sx.ResultCollect(mixed); r.Error() != "a" {
+		t.FailNow()
+	}
+	var values, errs = sx.ResultPartition(mixed)
+	if values.Length() != 2 || values.Get(0).Value() != 1 || values.Get(1).Value() != 3 {
+		t.FailNow()
+	}
+	if errs.Length() != 2 || errs.Get(0).Value().Error() != "a" || errs.Get(1).Value().Error() != "b" {
+		t.FailNow()
+	}
+}
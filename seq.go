@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import "iter"
+
+var _ Iterable[int, string] = FromSeq2[int, string](nil)
+var _ Iterator[int, string] = &seqIterator[int, string]{}
+
+// Converts an sx.Iterable into a go iterator over key/value pairs
+//
+// Every range loop creates a new sx.Iterator, so the sequence can be used multiple times
+//
+//	for key, value := range sx.Seq2(someMap) { ... }
+func Seq2[K any, V any](iterable Iterable[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := iterable.NewIterator(); it.Ok(); it.Next() {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Converts an sx.Iterable into a go iterator over its values
+func Seq[K any, V any](iterable Iterable[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for it := iterable.NewIterator(); it.Ok(); it.Next() {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Converts an sx.Iterable into a go iterator over its keys
+func Keys[K any, V any](iterable Iterable[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for it := iterable.NewIterator(); it.Ok(); it.Next() {
+			if !yield(it.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// Converts an sx.Iterator into a go iterator over key/value pairs
+//
+// The iterator is consumed, so the sequence can only be used once (e.g. for lazy pipelines)
+func IterSeq2[K any, V any](iterator Iterator[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for ; iterator.Ok(); iterator.Next() {
+			if !yield(iterator.Key(), iterator.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Converts an sx.Iterator into a go iterator over its values
+//
+// The iterator is consumed, so the sequence can only be used once (e.g. for lazy pipelines)
+func IterSeq[K any, V any](iterator Iterator[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for ; iterator.Ok(); iterator.Next() {
+			if !yield(iterator.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Converts a go iterator (e.g. maps.All) into an sx.Iterable
+//
+// Every call to NewIterator runs the whole sequence and iterates the collected items,
+// so stopping early never leaves the sequence suspended. The sequence must be finite
+func FromSeq2[K any, V any](seq iter.Seq2[K, V]) Iterable[K, V] {
+	return seqIterable[K, V]{seq: seq}
+}
+
+// Converts a go iterator (e.g. slices.Values) into an sx.Iterable, the keys are the positions (starting at 0)
+func FromSeq[V any](seq iter.Seq[V]) Iterable[int, V] {
+	return FromSeq2(func(yield func(int, V) bool) {
+		var index = 0
+		for value := range seq {
+			if !yield(index, value) {
+				return
+			}
+			index++
+		}
+	})
+}
+
+type seqIterable[K any, V any] struct {
+	seq iter.Seq2[K, V]
+}
+
+func (s seqIterable[K, V]) NewIterator() Iterator[K, V] {
+	var items = []Pair[K, V]{}
+	for key, value := range s.seq {
+		items = append(items, NewPair(key, value))
+	}
+	return &seqIterator[K, V]{items: items}
+}
+
+type seqIterator[K any, V any] struct {
+	items []Pair[K, V]
+}
+
+func (it *seqIterator[K, V]) Ok() bool { return len(it.items) > 0 }
+func (it *seqIterator[K, V]) Key() K   { return it.items[0].Key }
+func (it *seqIterator[K, V]) Value() V { return it.items[0].Value }
+func (it *seqIterator[K, V]) Next() {
+	if it.Ok() {
+		it.items = it.items[1:]
+	}
+}
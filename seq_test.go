@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"maps"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func TestRangeOverArray(t *testing.T) {
+	var arr = sx.NewArrayFrom("a", "b", "c")
+	var keys = []int{}
+	var values = []string{}
+	for k, v := range sx.Seq2(arr) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(keys, []int{0, 1, 2}) || !reflect.DeepEqual(values, []string{"a", "b", "c"}) {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(slices.Collect(sx.Seq(arr)), []string{"a", "b", "c"}) {
+		t.FailNow()
+	}
+
+	// the sequence reflects the array at the time the loop starts
+	var seq = sx.Seq(arr)
+	arr.Push("d")
+	if !reflect.DeepEqual(slices.Collect(seq), []string{"a", "b", "c", "d"}) {
+		t.FailNow()
+	}
+	for v := range sx.Seq(arr) {
+		if v == "b" {
+			break
+		}
+	}
+	for k := range sx.Seq2(arr) {
+		if k == 1 {
+			break
+		}
+	}
+}
+
+func TestRangeOverMaps(t *testing.T) {
+	var reference = map[string]int{"a": 1, "b": 2, "c": 3}
+	var sortedKeys = []string{"a", "b", "c"}
+	var all = []sx.Map[string, int]{
+		sx.NewMapFrom(reference),
+		sx.NewOrderedMapFrom(sx.NewPair("a", 1), sx.NewPair("b", 2), sx.NewPair("c", 3)),
+		sx.NewTreeMapFrom(func(a, b string) bool { return a < b }, reference),
+		sx.NewConcurrentMapFrom(reference),
+	}
+	for _, m := range all {
+		if !maps.Equal(maps.Collect(sx.Seq2(m)), reference) {
+			t.FailNow()
+		}
+		if !reflect.DeepEqual(slices.Sorted(sx.Keys(m)), sortedKeys) {
+			t.FailNow()
+		}
+	}
+	if !reflect.DeepEqual(slices.Collect(sx.Keys[int, int](sx.NewArrayFrom(5, 6))), []int{0, 1}) {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(slices.Sorted(maps.Keys(maps.Collect(sx.Seq2(sx.NewSetFrom(2, 1))))), []int{1, 2}) {
+		t.FailNow()
+	}
+}
+
+func TestSeqFromIterable(t *testing.T) {
+	var opt = sx.NewOptionalFrom(42)
+	if !reflect.DeepEqual(slices.Collect(sx.Seq[int, int](opt)), []int{42}) {
+		t.FailNow()
+	}
+	var m = sx.NewOrderedMapFrom(sx.NewPair("x", 1), sx.NewPair("y", 2))
+	var found = ""
+	for k, v := range sx.Seq2(m) {
+		found += sx.Str(k, v)
+		break
+	}
+	for range sx.Seq(m) {
+		break
+	}
+	for range sx.Keys(m) {
+		break
+	}
+	if found != "x1" {
+		t.FailNow()
+	}
+}
+
+func TestSeqFromIterator(t *testing.T) {
+	var arr = sx.NewArrayFrom(1, 2, 3, 4, 5, 6)
+	var evens = sx.Filter(arr.NewIterator(), isEven)
+	if !reflect.DeepEqual(slices.Collect(sx.IterSeq(evens)), []int{2, 4, 6}) {
+		t.FailNow()
+	}
+	var keys = []int{}
+	for k, v := range sx.IterSeq2(sx.Skip(arr.NewIterator(), 1)) {
+		keys = append(keys, k)
+		if v == 3 {
+			break
+		}
+	}
+	for range sx.IterSeq(arr.NewIterator()) {
+		break
+	}
+	if !reflect.DeepEqual(keys, []int{1, 2}) {
+		t.FailNow()
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	var reference = map[string]int{"a": 1, "b": 2}
+	var fromMap = sx.FromSeq2(maps.All(reference))
+	if sx.CollectMap(fromMap.NewIterator()).Length() != 2 {
+		t.FailNow()
+	}
+	var mapped = sx.MapValues(fromMap.NewIterator(), func(k string, v int) sx.Optional[string] { return sx.NewOptionalFrom(sx.Str(k, v)) })
+	if !reflect.DeepEqual(slices.Sorted(sx.Seq(mapped)), []string{"a1", "b2"}) {
+		t.FailNow()
+	}
+	if found := sx.FindAll(fromMap, func(k string, v int) bool { return v > 1 }); found.Length() != 1 || found.Get(0).Value() != sx.NewPair("b", 2) {
+		t.FailNow()
+	}
+	if !sx.ContainsValue(fromMap, 2) || sx.ContainsValue(fromMap, 3) {
+		t.FailNow()
+	}
+
+	var fromSlice = sx.FromSeq(slices.Values([]string{"x", "y", "z"}))
+	var it = fromSlice.NewIterator()
+	if !it.Ok() || it.Key() != 0 || it.Value() != "x" {
+		t.FailNow()
+	}
+	it.Next()
+	if !it.Ok() || it.Key() != 1 || it.Value() != "y" {
+		t.FailNow()
+	}
+	if first := sx.FindFirstWhere(fromSlice, func(k int, v string) bool { return v == "z" }); first.Value().Key != 2 {
+		t.FailNow()
+	}
+	if sx.Count(fromSlice.NewIterator()) != 3 || sx.Count(sx.FromSeq(slices.Values([]int{})).NewIterator()) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestFromSeqStopsEarly(t *testing.T) {
+	var stopped = 0
+	var numbers = sx.FromSeq(func(yield func(int) bool) {
+		defer func() { stopped++ }()
+		for i := range 10 {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	var goroutines = runtime.NumGoroutine()
+	for range 100 {
+		if !sx.Any(numbers.NewIterator(), func(k int, v int) bool { return v == 0 }) {
+			t.FailNow()
+		}
+		if sx.CollectArray(sx.Take(numbers.NewIterator(), 1)).Length() != 1 {
+			t.FailNow()
+		}
+		for range sx.Seq(numbers) {
+			break
+		}
+	}
+	if stopped != 300 || runtime.NumGoroutine() > goroutines {
+		t.Fatal(stopped, goroutines, runtime.NumGoroutine())
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Sorts the array in-place, the order of equal values is undefined
+func Sort[V any](arr Array[V], less func(a, b V) bool) {
+	if slice, ok := arraySlice(arr); ok {
+		sort.Slice(slice, func(i, j int) bool { return less(slice[i], slice[j]) })
+		return
+	}
+	sort.Sort(arraySorter[V]{arr: arr, less: less})
+}
+
+// Sorts the array in-place, equal values keep their original order
+func SortStable[V any](arr Array[V], less func(a, b V) bool) {
+	if slice, ok := arraySlice(arr); ok {
+		sort.SliceStable(slice, func(i, j int) bool { return less(slice[i], slice[j]) })
+		return
+	}
+	sort.Stable(arraySorter[V]{arr: arr, less: less})
+}
+
+// Sorts the array in-place by a key, e.g. a field of a struct
+//
+// The sort is stable and the key function is called once per value
+func SortBy[V any, K cmp.Ordered](arr Array[V], key func(value V) K) {
+	var keys = make([]K, arr.Length())
+	for it := arr.NewIterator(); it.Ok(); it.Next() {
+		keys[it.Key()] = key(it.Value())
+	}
+	sort.Stable(keyedArraySorter[V, K]{arr: arr, keys: keys})
+}
+
+// Checks if the array is sorted
+func IsSorted[V any](arr Array[V], less func(a, b V) bool) bool {
+	return sort.IsSorted(arraySorter[V]{arr: arr, less: less})
+}
+
+// Finds the index of a value in a sorted array
+//
+// If the value is present multiple times, the first index is returned
+func BinarySearch[V any](arr Array[V], value V, less func(a, b V) bool) Optional[int] {
+	var index = LowerBound(arr, value, less)
+	if index < arr.Length() && !less(value, arr.Get(index).Value()) {
+		return NewOptionalFrom(index)
+	}
+	return NewOptional[int]()
+}
+
+// Returns the first index in a sorted array whose value is not less than the given value
+//
+// Returns arr.Length() if all values are less than the given value
+func LowerBound[V any](arr Array[V], value V, less func(a, b V) bool) int {
+	return sort.Search(arr.Length(), func(i int) bool { return !less(arr.Get(i).Value(), value) })
+}
+
+// Returns the first index in a sorted array whose value is greater than the given value
+//
+// Returns arr.Length() if no value is greater than the given value
+func UpperBound[V any](arr Array[V], value V, less func(a, b V) bool) int {
+	return sort.Search(arr.Length(), func(i int) bool { return less(value, arr.Get(i).Value()) })
+}
+
+// Returns the backing slice of arrays that store their values in a go slice
+func arraySlice[V any](arr Array[V]) ([]V, bool) {
+	if impl, ok := arr.(*arrayImpl[V]); ok {
+		return *impl, true
+	}
+	return nil, false
+}
+
+// Sorts any sx.Array via Get and Put
+type arraySorter[V any] struct {
+	arr  Array[V]
+	less func(a, b V) bool
+}
+
+func (s arraySorter[V]) Len() int { return s.arr.Length() }
+func (s arraySorter[V]) Less(i, j int) bool {
+	return s.less(s.arr.Get(i).Value(), s.arr.Get(j).Value())
+}
+func (s arraySorter[V]) Swap(i, j int) {
+	var vi, vj = s.arr.Get(i).Value(), s.arr.Get(j).Value()
+	s.arr.Put(i, vj)
+	s.arr.Put(j, vi)
+}
+
+type keyedArraySorter[V any, K cmp.Ordered] struct {
+	arr  Array[V]
+	keys []K
+}
+
+func (s keyedArraySorter[V, K]) Len() int           { return len(s.keys) }
+func (s keyedArraySorter[V, K]) Less(i, j int) bool { return cmp.Less(s.keys[i], s.keys[j]) }
+func (s keyedArraySorter[V, K]) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	var vi, vj = s.arr.Get(i).Value(), s.arr.Get(j).Value()
+	s.arr.Put(i, vj)
+	s.arr.Put(j, vi)
+}
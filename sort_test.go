@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+// hides the concrete type, so the generic (Get/Put based) code paths are used
+type wrappedArray[V any] struct {
+	sx.Array[V]
+}
+
+type person struct {
+	Name string
+	Age  int
+}
+
+func TestSort(t *testing.T) {
+	for _, arr := range []sx.Array[int]{sx.NewArrayFrom(5, 2, 4, 1, 3), wrappedArray[int]{sx.NewArrayFrom(5, 2, 4, 1, 3)}} {
+		if sx.IsSorted(arr, intLess) {
+			t.FailNow()
+		}
+		sx.Sort(arr, intLess)
+		if !reflect.DeepEqual(arr.SubSlice(), []int{1, 2, 3, 4, 5}) || !sx.IsSorted(arr, intLess) {
+			t.FailNow()
+		}
+		sx.Sort(arr, func(a, b int) bool { return a > b })
+		if !reflect.DeepEqual(arr.SubSlice(), []int{5, 4, 3, 2, 1}) {
+			t.FailNow()
+		}
+	}
+	var empty = sx.NewArray[int]()
+	sx.Sort(empty, intLess)
+	if !sx.IsSorted(empty, intLess) {
+		t.FailNow()
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	var byAge = func(a, b person) bool { return a.Age < b.Age }
+	var expected = []person{{"b", 20}, {"d", 20}, {"a", 30}, {"c", 30}}
+	for _, arr := range []sx.Array[person]{
+		sx.NewArrayFrom(person{"a", 30}, person{"b", 20}, person{"c", 30}, person{"d", 20}),
+		wrappedArray[person]{sx.NewArrayFrom(person{"a", 30}, person{"b", 20}, person{"c", 30}, person{"d", 20})},
+	} {
+		sx.SortStable(arr, byAge)
+		if !reflect.DeepEqual(arr.SubSlice(), expected) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	var people = sx.NewArrayFrom(person{"c", 30}, person{"a", 20}, person{"b", 30}, person{"d", 10})
+	var calls = 0
+	sx.SortBy(people, func(p person) int { calls++; return p.Age })
+	if !reflect.DeepEqual(people.SubSlice(), []person{{"d", 10}, {"a", 20}, {"c", 30}, {"b", 30}}) || calls != 4 {
+		t.FailNow()
+	}
+	sx.SortBy(people, func(p person) string { return p.Name })
+	if !reflect.DeepEqual(people.SubSlice(), []person{{"a", 20}, {"b", 30}, {"c", 30}, {"d", 10}}) {
+		t.FailNow()
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	var arr = sx.NewArrayFrom(1, 3, 3, 3, 5, 7)
+	if sx.BinarySearch(arr, 3, intLess).Value() != 1 || sx.BinarySearch(arr, 7, intLess).Value() != 5 || sx.BinarySearch(arr, 1, intLess).Value() != 0 {
+		t.FailNow()
+	}
+	if sx.BinarySearch(arr, 0, intLess).Ok() || sx.BinarySearch(arr, 4, intLess).Ok() || sx.BinarySearch(arr, 8, intLess).Ok() {
+		t.FailNow()
+	}
+	if sx.BinarySearch(sx.NewArray[int](), 1, intLess).Ok() {
+		t.FailNow()
+	}
+	if sx.LowerBound(arr, 3, intLess) != 1 || sx.UpperBound(arr, 3, intLess) != 4 {
+		t.FailNow()
+	}
+	if sx.LowerBound(arr, 0, intLess) != 0 || sx.UpperBound(arr, 0, intLess) != 0 {
+		t.FailNow()
+	}
+	if sx.LowerBound(arr, 8, intLess) != 6 || sx.UpperBound(arr, 7, intLess) != 6 {
+		t.FailNow()
+	}
+	if sx.LowerBound(arr, 4, intLess) != 4 || sx.UpperBound(arr, 4, intLess) != 4 {
+		t.FailNow()
+	}
+}
+
+func TestSortRandomized(t *testing.T) {
+	var random = rand.New(rand.NewSource(7))
+	var arr = sx.NewArray[int]()
+	var reference = []int{}
+	for i := 0; i < 1000; i++ {
+		var value = random.Intn(100)
+		arr.Push(value)
+		reference = append(reference, value)
+	}
+	sx.Sort(arr, intLess)
+	sort.Ints(reference)
+	if !reflect.DeepEqual(arr.SubSlice(), reference) {
+		t.FailNow()
+	}
+	for value := 0; value < 100; value++ {
+		var lower, upper = sx.LowerBound(arr, value, intLess), sx.UpperBound(arr, value, intLess)
+		if lower != sort.SearchInts(reference, value) || upper != sort.SearchInts(reference, value+1) {
+			t.FailNow()
+		}
+		if sx.BinarySearch(arr, value, intLess).Ok() != (lower < upper) {
+			t.FailNow()
+		}
+	}
+}
@@ -3,68 +3,42 @@ package sx
 
 import (
 	"fmt"
-	"os"
-	"strconv"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 func Print(values ...string) {
-	fmt.Fprint(os.Stdout, StrCat(values...))
+	StdOut().Print(values...)
 }
 
 func PrintLn(values ...string) {
-	fmt.Fprintln(os.Stdout, StrCat(values...))
+	StdOut().PrintLn(values...)
 }
 
 func PrintStdErr(values ...string) {
-	fmt.Fprint(os.Stderr, StrCat(values...))
+	StdErr().Print(values...)
 }
 
 func PrintStdErrLn(values ...string) {
-	fmt.Fprintln(os.Stderr, StrCat(values...))
+	StdErr().PrintLn(values...)
 }
 
 func PrintAny(values ...any) {
-	fmt.Fprint(os.Stdout, Str(values...))
+	StdOut().PrintAny(values...)
 }
 
 func PrintAnyLn(values ...any) {
-	fmt.Fprintln(os.Stdout, Str(values...))
+	StdOut().PrintAnyLn(values...)
 }
 
 func PrintAnyStdErr(values ...any) {
-	fmt.Fprint(os.Stderr, Str(values...))
+	StdErr().PrintAny(values...)
 }
 
 func PrintAnyStdErrLn(values ...any) {
-	fmt.Fprintln(os.Stderr, Str(values...))
-}
-
-func String2Int(s string) Result[int64] {
-	var val, e = strconv.ParseInt(s, 10, 64)
-	if e != nil {
-		NewResultError[int64]("Fatal error: cannot convert string to int64")
-	}
-	return NewResultFrom(val)
-}
-
-func String2Float(s string) Result[float64] {
-	var val, e = strconv.ParseFloat(s, 64)
-	if e != nil {
-		NewResultError[int64]("Fatal error: cannot convert string to float64")
-	}
-	return NewResultFrom(val)
-}
-
-func String2Bool(s string) Result[bool] {
-	switch s {
-	default:
-		return NewResultError[bool]("Fatal error: cannot convert string to bool")
-	case "true":
-		return NewResultFrom(true)
-	case "false":
-		return NewResultFrom(false)
-	}
+	StdErr().PrintAnyLn(values...)
 }
 
 // Concatenates strings without adding any extra (white-)spaces
@@ -89,12 +63,257 @@ func Str(values ...any) string {
 	}
 }
 
+// Splits the string at each separator, see strings.Split
+func StrSplit(s string, separator string) Array[string] {
+	return NewArrayFrom(strings.Split(s, separator)...)
+}
+
+// Pads the string on the left to 'width' runes, the default padding is ' '
+func StrPadLeft(s string, width int, padding ...rune) string {
+	return StrCat(strPadding(s, width, padding), s)
+}
+
+// Pads the string on the right to 'width' runes, the default padding is ' '
+func StrPadRight(s string, width int, padding ...rune) string {
+	return StrCat(s, strPadding(s, width, padding))
+}
+
+func strPadding(s string, width int, padding []rune) string {
+	var padRune = ' '
+	if len(padding) > 0 {
+		padRune = padding[0]
+	}
+	var missing = width - utf8.RuneCountInString(s)
+	if missing <= 0 {
+		return ""
+	}
+	return strings.Repeat(string(padRune), missing)
+}
+
+// Shortens the string to at most 'maxLength' runes, the end is replaced by the ellipsis (default "...")
+func StrTruncate(s string, maxLength int, ellipsis ...string) string {
+	var runes = []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	var ellipsisRunes = []rune("...")
+	if len(ellipsis) > 0 {
+		ellipsisRunes = []rune(ellipsis[0])
+	}
+	if len(ellipsisRunes) >= maxLength {
+		return string(ellipsisRunes[:max(maxLength, 0)])
+	}
+	return string(runes[:maxLength-len(ellipsisRunes)]) + string(ellipsisRunes)
+}
+
+// Reverses the runes of the string (not the bytes), so multi-byte characters stay intact
+func StrReverse(s string) string {
+	var runes = []rune(s)
+	slices.Reverse(runes)
+	return string(runes)
+}
+
+// Wraps each line at whitespace, so lines are at most 'width' runes long
+//
+// Words longer than 'width' are not split, they get a line of their own
+func StrWrap(s string, width int) string {
+	var sb = NewStringBuilder()
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			sb.WriteStrings("\n")
+		}
+		var lineLength = 0
+		for _, word := range strings.Fields(line) {
+			var wordLength = utf8.RuneCountInString(word)
+			if lineLength > 0 && lineLength+1+wordLength > width {
+				sb.WriteStrings("\n")
+				lineLength = 0
+			} else if lineLength > 0 {
+				sb.WriteStrings(" ")
+				lineLength++
+			}
+			sb.WriteStrings(word)
+			lineLength += wordLength
+		}
+	}
+	return sb.String()
+}
+
+// Puts the prefix in front of every line that is not empty
+func StrIndent(s string, prefix string) string {
+	var lines = strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Removes the leading whitespace that all lines have in common, lines with only whitespace are ignored
+func StrDedent(s string) string {
+	var lines = strings.Split(s, "\n")
+	var common = ""
+	var first = true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var indentation = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			common, first = indentation, false
+		}
+		for !strings.HasPrefix(indentation, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = strings.TrimLeft(line, " \t")
+		} else {
+			lines[i] = line[len(common):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Converts e.g. "HTTPServer name" to "http_server_name"
+func StrSnakeCase(s string) string {
+	return strings.ToLower(strings.Join(strWords(s), "_"))
+}
+
+// Converts e.g. "HTTPServer name" to "http-server-name"
+func StrKebabCase(s string) string {
+	return strings.ToLower(strings.Join(strWords(s), "-"))
+}
+
+// Converts e.g. "http_server name" to "httpServerName"
+func StrCamelCase(s string) string {
+	var words = strWords(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = strCapitalize(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// Converts e.g. "http_server name" to "Http Server Name"
+func StrTitleCase(s string) string {
+	var words = strWords(s)
+	for i, word := range words {
+		words[i] = strCapitalize(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func strCapitalize(word string) string {
+	var first, size = utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(first)) + strings.ToLower(word[size:])
+}
+
+// Splits at everything that is not a letter or digit, and at camel case boundaries ("HTTPServer" is "HTTP", "Server")
+func strWords(s string) []string {
+	var words = []string{}
+	var runes = []rune(s)
+	var start = -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		var previous = runes[i-1]
+		var lowerToUpper = unicode.IsUpper(r) && !unicode.IsUpper(previous)
+		var acronymEnd = unicode.IsUpper(previous) && unicode.IsUpper(r) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if lowerToUpper || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// Replaces named placeholders like "{name}" with the values of the map, "{{" and "}}" are literal braces
+//
+// Fails if a placeholder is not closed or its name is not in the map
+func StrFormat(template string, values Map[string, any]) Result[string] {
+	var sb = NewStringBuilder()
+	for i := 0; i < len(template); i++ {
+		switch {
+		case strings.HasPrefix(template[i:], "{{"), strings.HasPrefix(template[i:], "}}"):
+			sb.WriteByte(template[i])
+			i++
+		case template[i] == '{':
+			var end = strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return NewResultError[string]("Fatal error: unclosed placeholder in '", template, "'")
+			}
+			var name = template[i+1 : i+end]
+			var value = values.Get(name)
+			if !value.Ok() {
+				return NewResultError[string]("Fatal error: no value for placeholder '", name, "' in '", template, "'")
+			}
+			sb.WriteAny(value.Value())
+			i += end
+		default:
+			sb.WriteByte(template[i])
+		}
+	}
+	return NewResultFrom(sb.String())
+}
+
 type StringBuilder struct {
 	strings.Builder
+	indentation     int
+	indentationUnit string
 }
 
 func NewStringBuilder() *StringBuilder {
-	return &StringBuilder{strings.Builder{}}
+	return &StringBuilder{}
+}
+
+// Sets the string used per indentation level by WriteLine, the default is a tab
+func (sb *StringBuilder) SetIndentationUnit(unit string) *StringBuilder {
+	sb.indentationUnit = unit
+	return sb
+}
+
+// Increases the indentation of the following WriteLine calls by one level
+func (sb *StringBuilder) Indent() *StringBuilder {
+	sb.indentation++
+	return sb
+}
+
+// Decreases the indentation of the following WriteLine calls by one level
+func (sb *StringBuilder) Dedent() *StringBuilder {
+	sb.indentation = max(sb.indentation-1, 0)
+	return sb
+}
+
+// Writes the current indentation, the values and a newline. Empty lines are not indented
+func (sb *StringBuilder) WriteLine(values ...string) *StringBuilder {
+	var line = StrCat(values...)
+	if line != "" {
+		var unit = sb.indentationUnit
+		if unit == "" {
+			unit = "\t"
+		}
+		sb.WriteString(strings.Repeat(unit, sb.indentation))
+	}
+	sb.WriteStrings(line, "\n")
+	return sb
 }
 
 func (sb *StringBuilder) WriteStrings(values ...string) *StringBuilder {
@@ -108,3 +327,17 @@ func (sb *StringBuilder) WriteAny(values ...any) *StringBuilder {
 	}
 	return sb
 }
+
+// Writes all values of the iterable with the separator between them
+//
+// This is a function and not a method, because methods cannot have type parameters
+func WriteJoined[K any, V any](sb *StringBuilder, separator string, values Iterable[K, V]) *StringBuilder {
+	for it, first := values.NewIterator(), true; it.Ok(); it.Next() {
+		if !first {
+			sb.WriteString(separator)
+		}
+		sb.WriteAny(it.Value())
+		first = false
+	}
+	return sb
+}
@@ -86,3 +86,92 @@ func TestStringBuilder(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestStringBuilderLines(t *testing.T) {
+	var sb = sx.NewStringBuilder()
+	sb.WriteLine("func main() {").Indent()
+	sb.WriteLine("if ok {").Indent().WriteLine("return").Dedent().WriteLine("}")
+	sb.WriteLine().Dedent().Dedent().WriteLine("}")
+	if sb.String() != "func main() {\n\tif ok {\n\t\treturn\n\t}\n\n}\n" {
+		t.FailNow()
+	}
+
+	sb = sx.NewStringBuilder().SetIndentationUnit("  ")
+	sb.Indent().WriteLine("values: ", "[")
+	sx.WriteJoined(sb, ", ", sx.NewArrayFrom(1, 2, 3))
+	sx.WriteJoined(sb, ", ", sx.NewArray[int]())
+	sb.WriteLine("]")
+	if sb.String() != "  values: [\n1, 2, 3  ]\n" {
+		t.FailNow()
+	}
+}
+
+func TestStrSplitAndPad(t *testing.T) {
+	if parts := sx.StrSplit("a,b,,c", ","); parts.Length() != 4 || parts.Get(3).Value() != "c" || parts.Get(2).Value() != "" {
+		t.FailNow()
+	}
+	if sx.StrPadLeft("7", 3, '0') != "007" || sx.StrPadRight("ä", 3) != "ä  " || sx.StrPadLeft("long", 2) != "long" {
+		t.FailNow()
+	}
+}
+
+func TestStrTruncateAndReverse(t *testing.T) {
+	if sx.StrTruncate("hello world", 8) != "hello..." || sx.StrTruncate("hello", 5) != "hello" {
+		t.FailNow()
+	}
+	if sx.StrTruncate("äöüäöü", 4, "…") != "äöü…" || sx.StrTruncate("hello", 2) != ".." || sx.StrTruncate("hello", -1) != "" {
+		t.FailNow()
+	}
+	if sx.StrReverse("abc") != "cba" || sx.StrReverse("häß€") != "€ßäh" || sx.StrReverse("") != "" {
+		t.FailNow()
+	}
+}
+
+func TestStrWrap(t *testing.T) {
+	if wrapped := sx.StrWrap("the quick brown fox jumps", 10); wrapped != "the quick\nbrown fox\njumps" {
+		t.Fatal(wrapped)
+	}
+	if wrapped := sx.StrWrap("a extraordinarily b\n\nc  d", 5); wrapped != "a\nextraordinarily\nb\n\nc d" {
+		t.Fatal(wrapped)
+	}
+}
+
+func TestStrIndentAndDedent(t *testing.T) {
+	if sx.StrIndent("a\n\nb", "> ") != "> a\n\n> b" {
+		t.FailNow()
+	}
+	if dedented := sx.StrDedent("    if x {\n      y\n  \n    }"); dedented != "if x {\n  y\n\n}" {
+		t.Fatal(dedented)
+	}
+	if sx.StrDedent("\ta\n  b") != "\ta\n  b" || sx.StrDedent("  a\n  b") != "a\nb" || sx.StrDedent("") != "" {
+		t.FailNow()
+	}
+}
+
+func TestStrCaseConversions(t *testing.T) {
+	var expected = []struct{ input, snake, kebab, camel, title string }{
+		{"HTTPServer name", "http_server_name", "http-server-name", "httpServerName", "Http Server Name"},
+		{"userID", "user_id", "user-id", "userId", "User Id"},
+		{"already_snake_case", "already_snake_case", "already-snake-case", "alreadySnakeCase", "Already Snake Case"},
+		{"  version2Update--now ", "version2_update_now", "version2-update-now", "version2UpdateNow", "Version2 Update Now"},
+		{"", "", "", "", ""},
+	}
+	for _, e := range expected {
+		if sx.StrSnakeCase(e.input) != e.snake || sx.StrKebabCase(e.input) != e.kebab || sx.StrCamelCase(e.input) != e.camel || sx.StrTitleCase(e.input) != e.title {
+			t.Fatal(e.input)
+		}
+	}
+}
+
+func TestStrFormat(t *testing.T) {
+	var values = sx.NewMapFrom(map[string]any{"name": "Bob", "count": 3})
+	if s := sx.StrFormat("{name} has {count} {{items}}", values).Value(); s != "Bob has 3 {items}" {
+		t.Fatal(s)
+	}
+	if r := sx.StrFormat("{missing}", values); r.Error() != "Fatal error: no value for placeholder 'missing' in '{missing}'" {
+		t.FailNow()
+	}
+	if sx.StrFormat("{name", values).Ok() || sx.StrFormat("a } b", values).Value() != "a } b" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import "iter"
+
+var _ Container = &treeMapImpl[string, int]{}
+var _ Map[string, int] = NewTreeMap[string, int](func(a, b string) bool { return a < b })
+var _ SortedMap[string, int] = &treeMapImpl[string, int]{}
+var _ Iterable[string, int] = &treeMapImpl[string, int]{}
+var _ Iterator[string, int] = &treeMapIterator[string, int]{}
+
+// Creates a sorted map, backed by a self-balancing binary search tree (AVL tree)
+//
+// 'less' defines the order of the keys. Two keys are equal if neither is less than the other.
+// Has/Get/Put/Drop are O(log n), iteration is in sorted order
+func NewTreeMap[K comparable, V any](less func(a, b K) bool) SortedMap[K, V] {
+	return &treeMapImpl[K, V]{less: less}
+}
+
+// Creates a sorted map from the values of a go map
+func NewTreeMapFrom[K comparable, V any](less func(a, b K) bool, values map[K]V) SortedMap[K, V] {
+	var m = NewTreeMap[K, V](less)
+	for key, value := range values {
+		m.Put(key, value)
+	}
+	return m
+}
+
+type treeMapNode[K comparable, V any] struct {
+	key    K
+	value  V
+	left   *treeMapNode[K, V]
+	right  *treeMapNode[K, V]
+	height int
+}
+
+type treeMapImpl[K comparable, V any] struct {
+	root   *treeMapNode[K, V]
+	length int
+	less   func(a, b K) bool
+}
+
+func (m *treeMapImpl[K, V]) Length() int {
+	return m.length
+}
+
+func (m *treeMapImpl[K, V]) IsEmpty() bool {
+	return m.Length() == 0
+}
+
+func (m *treeMapImpl[K, V]) Has(key K) bool {
+	return m.find(key) != nil
+}
+
+func (m *treeMapImpl[K, V]) Get(key K) Result[V] {
+	var node = m.find(key)
+	if node == nil {
+		return NewResultError[V]("Fatal error: Key does not exist")
+	}
+	return NewResultFrom(node.value)
+}
+
+func (m *treeMapImpl[K, V]) Put(key K, value V) bool {
+	var added bool
+	m.root, added = m.insert(m.root, key, value)
+	if added {
+		m.length++
+	}
+	return true
+}
+
+func (m *treeMapImpl[K, V]) Drop(key K) bool {
+	var removed bool
+	m.root, removed = m.remove(m.root, key)
+	if removed {
+		m.length--
+	}
+	return removed
+}
+
+func (m *treeMapImpl[K, V]) Min() Optional[Pair[K, V]] {
+	return m.first().pair()
+}
+
+func (m *treeMapImpl[K, V]) Max() Optional[Pair[K, V]] {
+	var node = m.root
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+	return node.pair()
+}
+
+func (m *treeMapImpl[K, V]) Floor(key K) Optional[Pair[K, V]] {
+	var floor *treeMapNode[K, V]
+	for node := m.root; node != nil; {
+		if m.less(key, node.key) {
+			node = node.left
+		} else {
+			floor = node
+			node = node.right
+		}
+	}
+	return floor.pair()
+}
+
+func (m *treeMapImpl[K, V]) Ceiling(key K) Optional[Pair[K, V]] {
+	return m.ceiling(key).pair()
+}
+
+// Iterates all entries with from <= key < to in sorted order
+func (m *treeMapImpl[K, V]) Range(from K, to K) Iterator[K, V] {
+	return &treeMapIterator[K, V]{tree: m, node: m.ceiling(from), to: NewOptionalFrom(to)}
+}
+
+func (m *treeMapImpl[K, V]) NewIterator() Iterator[K, V] {
+	return &treeMapIterator[K, V]{tree: m, node: m.first()}
+}
+
+func (m *treeMapImpl[K, V]) All() iter.Seq2[K, V] {
+	return Seq2[K, V](m)
+}
+
+func (m *treeMapImpl[K, V]) first() *treeMapNode[K, V] {
+	var node = m.root
+	for node != nil && node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+func (m *treeMapImpl[K, V]) find(key K) *treeMapNode[K, V] {
+	var node = m.root
+	for node != nil {
+		if m.less(key, node.key) {
+			node = node.left
+		} else if m.less(node.key, key) {
+			node = node.right
+		} else {
+			return node
+		}
+	}
+	return nil
+}
+
+// Finds the node with the smallest key >= key
+func (m *treeMapImpl[K, V]) ceiling(key K) *treeMapNode[K, V] {
+	var ceiling *treeMapNode[K, V]
+	for node := m.root; node != nil; {
+		if m.less(node.key, key) {
+			node = node.right
+		} else {
+			ceiling = node
+			node = node.left
+		}
+	}
+	return ceiling
+}
+
+// Finds the node with the smallest key > key
+func (m *treeMapImpl[K, V]) higher(key K) *treeMapNode[K, V] {
+	var higher *treeMapNode[K, V]
+	for node := m.root; node != nil; {
+		if m.less(key, node.key) {
+			higher = node
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return higher
+}
+
+func (m *treeMapImpl[K, V]) insert(node *treeMapNode[K, V], key K, value V) (*treeMapNode[K, V], bool) {
+	if node == nil {
+		return &treeMapNode[K, V]{key: key, value: value, height: 1}, true
+	}
+	var added bool
+	if m.less(key, node.key) {
+		node.left, added = m.insert(node.left, key, value)
+	} else if m.less(node.key, key) {
+		node.right, added = m.insert(node.right, key, value)
+	} else {
+		node.value = value
+		return node, false
+	}
+	return node.rebalance(), added
+}
+
+// Nodes are never copied into each other, so iterators can keep pointing to their node
+func (m *treeMapImpl[K, V]) remove(node *treeMapNode[K, V], key K) (*treeMapNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+	var removed bool
+	if m.less(key, node.key) {
+		node.left, removed = m.remove(node.left, key)
+	} else if m.less(node.key, key) {
+		node.right, removed = m.remove(node.right, key)
+	} else {
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+		var successor *treeMapNode[K, V]
+		var right = node.right
+		right, successor = right.removeMin()
+		successor.left = node.left
+		successor.right = right
+		return successor.rebalance(), true
+	}
+	return node.rebalance(), removed
+}
+
+func (node *treeMapNode[K, V]) removeMin() (rest *treeMapNode[K, V], smallest *treeMapNode[K, V]) {
+	if node.left == nil {
+		return node.right, node
+	}
+	node.left, smallest = node.left.removeMin()
+	return node.rebalance(), smallest
+}
+
+func (node *treeMapNode[K, V]) pair() Optional[Pair[K, V]] {
+	if node == nil {
+		return NewOptional[Pair[K, V]]()
+	}
+	return NewOptionalFrom(NewPair(node.key, node.value))
+}
+
+func (node *treeMapNode[K, V]) getHeight() int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func (node *treeMapNode[K, V]) updateHeight() {
+	var left, right = node.left.getHeight(), node.right.getHeight()
+	if left > right {
+		node.height = left + 1
+	} else {
+		node.height = right + 1
+	}
+}
+
+func (node *treeMapNode[K, V]) balance() int {
+	return node.left.getHeight() - node.right.getHeight()
+}
+
+func (node *treeMapNode[K, V]) rotateLeft() *treeMapNode[K, V] {
+	var newRoot = node.right
+	node.right = newRoot.left
+	newRoot.left = node
+	node.updateHeight()
+	newRoot.updateHeight()
+	return newRoot
+}
+
+func (node *treeMapNode[K, V]) rotateRight() *treeMapNode[K, V] {
+	var newRoot = node.left
+	node.left = newRoot.right
+	newRoot.right = node
+	node.updateHeight()
+	newRoot.updateHeight()
+	return newRoot
+}
+
+func (node *treeMapNode[K, V]) rebalance() *treeMapNode[K, V] {
+	node.updateHeight()
+	switch balance := node.balance(); {
+	case balance > 1:
+		if node.left.balance() < 0 {
+			node.left = node.left.rotateLeft()
+		}
+		return node.rotateRight()
+	case balance < -1:
+		if node.right.balance() > 0 {
+			node.right = node.right.rotateRight()
+		}
+		return node.rotateLeft()
+	}
+	return node
+}
+
+// Iterates in sorted order. The next key is searched from the root,
+// so entries may be dropped or added while iterating
+type treeMapIterator[K comparable, V any] struct {
+	tree *treeMapImpl[K, V]
+	node *treeMapNode[K, V]
+	to   Optional[K] // exclusive upper bound for range iterators
+}
+
+func (it *treeMapIterator[K, V]) Ok() bool {
+	return it.node != nil && (it.to.IsEmpty() || it.tree.less(it.node.key, it.to.Value()))
+}
+
+func (it *treeMapIterator[K, V]) Key() K {
+	return it.node.key
+}
+
+func (it *treeMapIterator[K, V]) Value() V {
+	return it.node.value
+}
+
+func (it *treeMapIterator[K, V]) Next() {
+	if it.node != nil {
+		it.node = it.tree.higher(it.node.key)
+	}
+}
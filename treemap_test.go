@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func collectKeys[K any, V any](it sx.Iterator[K, V]) []K {
+	var keys = []K{}
+	for ; it.Ok(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+func TestTreeMap(t *testing.T) {
+	var m = sx.NewTreeMap[int, string](intLess)
+	if !m.IsEmpty() || m.Min().Ok() || m.Max().Ok() || m.Floor(1).Ok() || m.Ceiling(1).Ok() || m.NewIterator().Ok() {
+		t.FailNow()
+	}
+	for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+		m.Put(key, sx.Str(key))
+	}
+	m.Put(30, "thirty")
+	if m.Length() != 7 || m.Get(30).Value() != "thirty" || m.Get(31).Ok() {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(collectKeys(m.NewIterator()), []int{10, 20, 30, 50, 70, 80, 90}) {
+		t.FailNow()
+	}
+	if m.Min().Value() != sx.NewPair(10, "10") || m.Max().Value() != sx.NewPair(90, "90") {
+		t.FailNow()
+	}
+	if m.Floor(55).Value().Key != 50 || m.Floor(50).Value().Key != 50 || m.Floor(9).Ok() {
+		t.FailNow()
+	}
+	if m.Ceiling(55).Value().Key != 70 || m.Ceiling(70).Value().Key != 70 || m.Ceiling(91).Ok() {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(collectKeys(m.Range(20, 80)), []int{20, 30, 50, 70}) {
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(collectKeys(m.Range(21, 79)), []int{30, 50, 70}) {
+		t.FailNow()
+	}
+	if len(collectKeys(m.Range(91, 100))) != 0 || len(collectKeys(m.Range(50, 50))) != 0 {
+		t.FailNow()
+	}
+	var values = []string{}
+	for it := m.Range(0, 25); it.Ok(); it.Next() {
+		values = append(values, it.Value())
+	}
+	if !reflect.DeepEqual(values, []string{"10", "20"}) {
+		t.FailNow()
+	}
+}
+
+func TestTreeMapIterationWithDeletion(t *testing.T) {
+	var m = sx.NewTreeMapFrom(intLess, map[int]string{1: "a", 2: "b", 3: "c", 4: "d", 5: "e"})
+	var found = []int{}
+	for it := m.NewIterator(); it.Ok(); it.Next() {
+		found = append(found, it.Key())
+		if it.Key() == 2 {
+			m.Drop(2)
+			m.Drop(3)
+			m.Put(6, "f")
+		}
+	}
+	if !reflect.DeepEqual(found, []int{1, 2, 4, 5, 6}) {
+		t.FailNow()
+	}
+}
+
+func TestTreeMapRandomized(t *testing.T) {
+	var random = rand.New(rand.NewSource(42))
+	var m = sx.NewTreeMap[int, int](intLess)
+	var reference = map[int]int{}
+	for i := 0; i < 5000; i++ {
+		var key = random.Intn(500)
+		if random.Intn(3) == 0 {
+			var _, exists = reference[key]
+			if m.Drop(key) != exists {
+				t.FailNow()
+			}
+			delete(reference, key)
+		} else {
+			m.Put(key, i)
+			reference[key] = i
+		}
+		if m.Length() != len(reference) {
+			t.FailNow()
+		}
+	}
+	var sortedKeys = []int{}
+	for key, value := range reference {
+		sortedKeys = append(sortedKeys, key)
+		if m.Get(key).Value() != value {
+			t.FailNow()
+		}
+	}
+	sort.Ints(sortedKeys)
+	if !reflect.DeepEqual(collectKeys(m.NewIterator()), sortedKeys) {
+		t.FailNow()
+	}
+}
+
+func TestTreeMapIsBalanced(t *testing.T) {
+	var comparisons = 0
+	var m = sx.NewTreeMap[int, int](func(a, b int) bool { comparisons++; return a < b })
+	for i := 0; i < 1<<16; i++ {
+		m.Put(i, i) // sorted input degenerates an unbalanced tree to a list
+	}
+	comparisons = 0
+	m.Get(0)
+	m.Get(1<<16 - 1)
+	if comparisons > 2*2*17*2 {
+		t.Fatal("too many comparisons: ", comparisons)
+	}
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: 0BSD
+package sx
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var _ Iterator[string, fs.DirEntry] = &walkIterator{}
+
+// Options for Dir.Walk, the zero value walks the whole subtree without following symlinks
+type WalkOptions struct {
+	MaxDepth       int      // 1 only yields the direct entries, 2 also their children, ... 0 means unlimited
+	FollowSymlinks bool     // descends into symlinked directories, each directory is visited at most once per path
+	Include        []string // glob patterns (see GlobMatch), only matching entries are yielded. Empty means all
+	Exclude        []string // glob patterns (see GlobMatch), matching entries are skipped, directories including their content
+
+	Prune   func(relativePath string, entry fs.DirEntry) bool // return true to skip the content of a directory
+	OnError func(relativePath string, err error)              // called for directories that cannot be read, they are skipped
+}
+
+// Iterates the whole subtree of the directory, depth-first and sorted by name within each directory
+//
+// The keys are the paths relative to the directory, the directory itself is not part of the result.
+// Directories are read lazily, only when the iterator reaches them
+func (dir Dir) Walk(options ...WalkOptions) Iterator[string, fs.DirEntry] {
+	var it = &walkIterator{root: dir}
+	if len(options) > 0 {
+		it.options = options[0]
+	}
+	var info, err = dir.FileSystem().Stat(dir.String())
+	if err != nil {
+		it.reportError("", err)
+		return it
+	}
+	it.push("", info, 0)
+	it.Next()
+	return it
+}
+
+// Checks if the slash separated path matches the glob pattern
+//
+// Supports the syntax of path.Match, plus "**" as a whole path segment, which matches any number of segments.
+// Patterns without a slash are matched against the last path segment, e.g. "*.go" matches "a/b/c.go"
+func GlobMatch(pattern string, slashPath string) bool {
+	var pathSegments = strings.Split(slashPath, "/")
+	if !strings.Contains(pattern, "/") {
+		var matched, _ = path.Match(pattern, pathSegments[len(pathSegments)-1])
+		return matched
+	}
+	return globMatchSegments(strings.Split(pattern, "/"), pathSegments)
+}
+
+func globMatchSegments(pattern []string, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(segments); skip++ {
+				if globMatchSegments(pattern[1:], segments[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+type walkFrame struct {
+	relativePath string
+	info         fs.FileInfo // to detect symlink loops
+	entries      []fs.DirEntry
+	depth        int
+}
+
+type walkIterator struct {
+	root    Dir
+	options WalkOptions
+	stack   []*walkFrame
+	key     string
+	entry   fs.DirEntry
+	ok      bool
+}
+
+func (it *walkIterator) Ok() bool           { return it.ok }
+func (it *walkIterator) Key() string        { return it.key }
+func (it *walkIterator) Value() fs.DirEntry { return it.entry }
+
+func (it *walkIterator) reportError(relativePath string, err error) {
+	if it.options.OnError != nil {
+		it.options.OnError(relativePath, err)
+	}
+}
+
+func (it *walkIterator) push(relativePath string, info fs.FileInfo, depth int) {
+	var entries, err = it.root.FileSystem().ReadDir(StrCat(it.root.String(), relativePath))
+	if err != nil {
+		it.reportError(relativePath, err)
+		return
+	}
+	it.stack = append(it.stack, &walkFrame{relativePath: relativePath, info: info, entries: entries, depth: depth})
+}
+
+func (it *walkIterator) Next() {
+	it.ok = false
+	for len(it.stack) > 0 && !it.ok {
+		var frame = it.stack[len(it.stack)-1]
+		if len(frame.entries) == 0 {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		var entry = frame.entries[0]
+		frame.entries = frame.entries[1:]
+		var relativePath = entry.Name()
+		if frame.relativePath != "" {
+			relativePath = StrCat(frame.relativePath, FilePathSeparator, entry.Name())
+		}
+		var slashPath = filepath.ToSlash(relativePath)
+		if globMatchAny(it.options.Exclude, slashPath) {
+			continue
+		}
+		it.visit(relativePath, entry, frame.depth+1)
+		if len(it.options.Include) == 0 || globMatchAny(it.options.Include, slashPath) {
+			it.key, it.entry, it.ok = relativePath, entry, true
+		}
+	}
+}
+
+// Descends into directories (and symlinks to directories, if enabled)
+func (it *walkIterator) visit(relativePath string, entry fs.DirEntry, depth int) {
+	if it.options.MaxDepth > 0 && depth >= it.options.MaxDepth {
+		return
+	}
+	var isSymlink = entry.Type()&fs.ModeSymlink != 0
+	if !entry.IsDir() && !(isSymlink && it.options.FollowSymlinks) {
+		return
+	}
+	var info, err = it.root.FileSystem().Stat(StrCat(it.root.String(), relativePath))
+	if err != nil || !info.IsDir() {
+		return
+	}
+	for _, ancestor := range it.stack {
+		if os.SameFile(ancestor.info, info) {
+			return
+		}
+	}
+	if it.options.Prune != nil && it.options.Prune(relativePath, entry) {
+		return
+	}
+	it.push(relativePath, info, depth)
+}
+
+func globMatchAny(patterns []string, slashPath string) bool {
+	for _, pattern := range patterns {
+		if GlobMatch(pattern, slashPath) {
+			return true
+		}
+	}
+	return false
+}
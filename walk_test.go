@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: 0BSD
+package sx_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ZeroBsd/sx"
+)
+
+// Creates a directory tree for the walk tests, directories end with '/'
+func newWalkTestDir(t *testing.T, paths ...string) sx.Dir {
+	var root = t.TempDir()
+	for _, p := range paths {
+		var full = filepath.Join(root, filepath.FromSlash(p))
+		if strings.HasSuffix(p, "/") {
+			sx.ThrowIfError(os.MkdirAll(full, 0755))
+		} else {
+			sx.ThrowIfError(os.MkdirAll(filepath.Dir(full), 0755))
+			sx.ThrowIfError(os.WriteFile(full, []byte(p), 0644))
+		}
+	}
+	return sx.NewDirFromString(root)
+}
+
+func walkKeys(it sx.Iterator[string, fs.DirEntry]) []string {
+	var keys = []string{}
+	for ; it.Ok(); it.Next() {
+		keys = append(keys, filepath.ToSlash(it.Key()))
+	}
+	return keys
+}
+
+func TestWalk(t *testing.T) {
+	var dir = newWalkTestDir(t, "a/x.go", "a/b/y.go", "a/b/c/z.txt", "top.go", "empty/", "node_modules/m.js")
+	var all = walkKeys(dir.Walk())
+	if !reflect.DeepEqual(all, []string{"a", "a/b", "a/b/c", "a/b/c/z.txt", "a/b/y.go", "a/x.go", "empty", "node_modules", "node_modules/m.js", "top.go"}) {
+		t.Fatal(all)
+	}
+	var it = dir.Walk()
+	if it.Key() != "a" || !it.Value().IsDir() || it.Value().Name() != "a" {
+		t.FailNow()
+	}
+
+	if keys := walkKeys(dir.Walk(sx.WalkOptions{MaxDepth: 1})); !reflect.DeepEqual(keys, []string{"a", "empty", "node_modules", "top.go"}) {
+		t.Fatal(keys)
+	}
+	if keys := walkKeys(dir.Walk(sx.WalkOptions{MaxDepth: 2, Include: []string{"*.go"}})); !reflect.DeepEqual(keys, []string{"a/x.go", "top.go"}) {
+		t.Fatal(keys)
+	}
+	if keys := walkKeys(dir.Walk(sx.WalkOptions{Include: []string{"a/**/*.go"}, Exclude: []string{"node_modules"}})); !reflect.DeepEqual(keys, []string{"a/b/y.go", "a/x.go"}) {
+		t.Fatal(keys)
+	}
+	if keys := walkKeys(dir.Walk(sx.WalkOptions{Exclude: []string{"a/b", "node_modules", "*.go"}})); !reflect.DeepEqual(keys, []string{"a", "empty"}) {
+		t.Fatal(keys)
+	}
+	var prune = func(relativePath string, entry fs.DirEntry) bool {
+		return entry.Name() == "b" || entry.Name() == "node_modules"
+	}
+	if keys := walkKeys(dir.Walk(sx.WalkOptions{Prune: prune})); !reflect.DeepEqual(keys, []string{"a", "a/b", "a/x.go", "empty", "node_modules", "top.go"}) {
+		t.Fatal(keys)
+	}
+}
+
+func TestWalkSymlinks(t *testing.T) {
+	if sx.RunningOnWindows {
+		t.Skip()
+	}
+	var dir = newWalkTestDir(t, "a/x.go", "a/b/y.go")
+	sx.ThrowIfError(os.Symlink(dir.String()+"a", dir.String()+"link"))
+	sx.ThrowIfError(os.Symlink("../..", dir.String()+"a/b/loop"))
+	sx.ThrowIfError(os.Symlink("x.go", dir.String()+"a/file"))
+
+	if keys := walkKeys(dir.Walk()); !reflect.DeepEqual(keys, []string{"a", "a/b", "a/b/loop", "a/b/y.go", "a/file", "a/x.go", "link"}) {
+		t.Fatal(keys)
+	}
+	// the loop back to the root is detected, the link to 'a' is followed once from the root
+	var keys = walkKeys(dir.Walk(sx.WalkOptions{FollowSymlinks: true}))
+	var expected = []string{"a", "a/b", "a/b/loop", "a/b/y.go", "a/file", "a/x.go", "link", "link/b", "link/b/loop", "link/b/y.go", "link/file", "link/x.go"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatal(keys)
+	}
+}
+
+func TestWalkErrors(t *testing.T) {
+	var errs = []string{}
+	var onError = func(relativePath string, err error) { errs = append(errs, relativePath) }
+	var missing = sx.NewDirFromString(filepath.Join(t.TempDir(), "missing"))
+	if missing.Walk(sx.WalkOptions{OnError: onError}).Ok() || !reflect.DeepEqual(errs, []string{""}) {
+		t.FailNow()
+	}
+	if missing.Walk().Ok() {
+		t.FailNow()
+	}
+	if sx.RunningOnWindows || os.Getuid() == 0 {
+		return
+	}
+	var dir = newWalkTestDir(t, "locked/x", "open/y")
+	sx.ThrowIfError(os.Chmod(dir.String()+"locked", 0))
+	defer os.Chmod(dir.String()+"locked", 0755)
+	if keys := walkKeys(dir.Walk(sx.WalkOptions{OnError: onError})); !reflect.DeepEqual(keys, []string{"locked", "open", "open/y"}) || errs[1] != "locked" {
+		t.Fatal(keys)
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	var matches = [][2]string{
+		{"*.go", "main.go"}, {"*.go", "a/b/main.go"}, {"a/*.go", "a/main.go"}, {"**/*.go", "main.go"},
+		{"**/*.go", "a/b/main.go"}, {"a/**", "a/b/c"}, {"a/**", "a"}, {"a/**/c", "a/c"}, {"a/**/c", "a/b/b/c"},
+		{"a/?/c", "a/b/c"}, {"a/[bc]/d", "a/c/d"},
+	}
+	for _, m := range matches {
+		if !sx.GlobMatch(m[0], m[1]) {
+			t.Fatal(m)
+		}
+	}
+	var mismatches = [][2]string{
+		{"*.go", "main.txt"}, {"a/*.go", "a/b/main.go"}, {"a/**/c", "a/b/d"}, {"a/b", "a/b/c"}, {"a/b/c", "a/b"}, {"a/[", "a/["}, {"[", "["},
+	}
+	for _, m := range mismatches {
+		if sx.GlobMatch(m[0], m[1]) {
+			t.Fatal(m)
+		}
+	}
+}